@@ -2,31 +2,137 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/AlexBurnes/version-go/pkg/version"
 )
 
-// bumpVersion bumps a version according to the specified bump type
-func bumpVersion(versionStr string, bumpTypeStr string) (string, error) {
+// bumpFlags holds the options of the bump command
+type bumpFlags struct {
+	opts  version.BumpOptions
+	style string // output style name, input keeps the style of the bumped version
+	pre   string // prerelease label started after the core bump (--pre rc)
+}
+
+// parseBumpArgs parses the bump command options and returns the remaining arguments
+func parseBumpArgs(args []string) ([]string, bumpFlags, error) {
+	var flags bumpFlags
+
+	fs := newCommandFlagSet("bump")
+	fs.BoolVar(&flags.opts.DropBuild, "drop-build", false, "drop build metadata from the bumped version")
+	module := fs.String("module", "", "module from .project.yml whose current version is bumped")
+	fs.StringVar(&flags.style, "style", "canonical", "output style (canonical, git, prefix, hyphen, input)")
+	fs.StringVar(&flags.pre, "pre", "", "prerelease label of the new core version (e.g. minor --pre rc)")
+	fs.StringVar(&flags.opts.Branch, "branch", "", "branch name of branch bumps instead of the current git branch")
+	fs.BoolVar(&flags.opts.AvoidExisting, "avoid-existing", false, "skip bumped versions that are already tagged")
+
+	rest, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return nil, flags, err
+	}
+	if flags.style != "input" {
+		if _, err := version.ParseStyle(flags.style); err != nil {
+			return nil, flags, err
+		}
+	}
+	if err := selectModule(*module); err != nil {
+		return nil, flags, err
+	}
+	return rest, flags, nil
+}
+
+// parseBumpSpec parses the bump type argument and adds the --pre label to it
+func parseBumpSpec(bumpTypeStr string, pre string) (version.BumpSpec, error) {
+	if pre != "" {
+		if strings.Contains(bumpTypeStr, "+") {
+			return version.BumpSpec{}, fmt.Errorf("--pre cannot be combined with compound bump type '%s'", bumpTypeStr)
+		}
+		bumpTypeStr += "+" + pre
+	}
+	return version.ParseBumpSpec(bumpTypeStr)
+}
+
+// formatBumped writes a bumped version in the output style, input keeps the style
+// of the bumped version. Git tags of a module carry its tag prefix (api/v1.2.3).
+func formatBumped(v *version.Version, styleStr string) (string, error) {
+	style := v.Style
+	if styleStr != "input" {
+		var err error
+		if style, err = version.ParseStyle(styleStr); err != nil {
+			return "", err
+		}
+	}
+	if style == version.StyleGit {
+		return currentModule.TagPrefix + v.ToGitTag(), nil
+	}
+	return v.Format(style), nil
+}
+
+// bumpVersion bumps a version according to the specified bump type, a single
+// type or core+label, and writes the bumped version in the output style
+func bumpVersion(versionStr string, bumpTypeStr string, flags bumpFlags) (string, error) {
+	result, err := bump(versionStr, bumpTypeStr, flags)
+	if err != nil {
+		return "", err
+	}
+	return formatBumped(result.Version, flags.style)
+}
+
+// bump bumps a version according to the specified bump type, reading the commits,
+// branch and existing tags from git when the bump needs them
+func bump(versionStr string, bumpTypeStr string, flags bumpFlags) (*version.BumpResult, error) {
 	// Parse bump type
-	bumpType, err := version.ParseBumpType(bumpTypeStr)
+	spec, err := parseBumpSpec(bumpTypeStr, flags.pre)
 	if err != nil {
-		return "", fmt.Errorf("invalid bump type '%s': %v", bumpTypeStr, err)
+		return nil, fmt.Errorf("invalid bump type '%s': %v", bumpTypeStr, err)
 	}
 
-	// Perform the bump operation
-	result, err := version.Bump(versionStr, bumpType)
+	// Branch bumps continue after the existing version tags of the branch
+	if spec.Core == version.BumpBranch && flags.opts.Branch == "" {
+		if flags.opts.Branch, err = version.GetBranch(); err != nil {
+			return nil, fmt.Errorf("failed to get branch for branch bump: %v (use --branch name)", err)
+		}
+		printDebug("Branch bump of %s", flags.opts.Branch)
+	}
+	// Branch bumps and --avoid-existing check the existing version tags of the module
+	if spec.Core == version.BumpBranch || flags.opts.AvoidExisting {
+		tags, e := version.GetModuleTags(currentModule)
+		if e != nil && !version.IsNoGitTags(e) {
+			if flags.opts.AvoidExisting {
+				return nil, fmt.Errorf("failed to get existing tags: %v", e)
+			}
+			printDebug("No existing version tags for branch bump: %v", e)
+		}
+		flags.opts.Tags = append([]string{}, tags...)
+		printDebug("Found %d existing version tags", len(flags.opts.Tags))
+	}
+
+	// Perform the bump operation, auto reads the commits since the current version tag
+	var result *version.BumpResult
+	if spec.Core == version.BumpAuto {
+		commits, e := version.GetModuleCommits(currentModule)
+		if e != nil {
+			return nil, fmt.Errorf("failed to get commits for auto bump: %v", e)
+		}
+		printDebug("Found %d commits since the current version tag", len(commits))
+		result, err = version.BumpFromCommits(versionStr, commits, flags.opts)
+	} else {
+		result, err = version.BumpWithSpec(versionStr, spec, flags.opts)
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to bump version '%s': %v", versionStr, err)
+		return nil, fmt.Errorf("failed to bump version '%s': %v", versionStr, err)
 	}
 
 	// Print debug information
 	if debugFlag {
 		printDebug("Bump operation: %s -> %s", result.OriginalVersion, result.BumpedVersion)
 		printDebug("Applied rule: %s", result.AppliedRule)
+		if len(result.Skipped) > 0 {
+			printDebug("Skipped existing versions: %s", strings.Join(result.Skipped, ", "))
+		}
 	}
 
-	return result.BumpedVersion, nil
+	return result, nil
 }
 
 // getBumpVersion gets the version to bump (from argument or current git version)
@@ -53,7 +159,7 @@ func getBumpVersion(args []string) (string, error) {
 		}
 		
 		// Check if it's a valid bump type
-		if _, err := version.ParseBumpType(arg); err == nil {
+		if _, err := version.ParseBumpSpec(arg); err == nil {
 			// It's a valid bump type, so no version provided - use current git version
 			version, err := getVersion()
 			if err != nil {
@@ -90,7 +196,7 @@ func getBumpType(args []string) string {
 		arg := args[0]
 		
 		// Check if it's a valid bump type
-		if _, err := version.ParseBumpType(arg); err == nil {
+		if _, err := version.ParseBumpSpec(arg); err == nil {
 			// It's a valid bump type
 			printDebug("Using provided bump type: %s", arg)
 			return arg
@@ -128,7 +234,7 @@ func validateBumpArgs(args []string) error {
 		}
 		
 		// Check if it's a valid bump type
-		if _, err := version.ParseBumpType(arg); err == nil {
+		if _, err := version.ParseBumpSpec(arg); err == nil {
 			// It's a valid bump type
 			return nil
 		}
@@ -142,7 +248,7 @@ func validateBumpArgs(args []string) error {
 		return fmt.Errorf("invalid version '%s': %v", args[0], err)
 	}
 
-	if _, err := version.ParseBumpType(args[1]); err != nil {
+	if _, err := version.ParseBumpSpec(args[1]); err != nil {
 		return fmt.Errorf("invalid bump type '%s': %v", args[1], err)
 	}
 
@@ -152,25 +258,57 @@ func validateBumpArgs(args []string) error {
 // printBumpHelp prints help information for the bump command
 func printBumpHelp() {
 	fmt.Printf(`Bump command usage:
-    version bump [version] [type]
+    version bump [version] [type] [options]
 
 Arguments:
     version    Version to bump (optional, uses current git version if not specified)
     type       Bump type (optional, defaults to 'smart')
 
+Options:
+    --drop-build   Drop build metadata (+meta) instead of carrying it to the bumped version
+    --module name  Bump the current version of a module from .project.yml (tags such as api/v1.2.3)
+    --pre label    Start a prerelease of the new core version (minor --pre rc, same as minor+rc)
+    --branch name  Branch of a branch bump instead of the current git branch (e.g. in a detached CI checkout)
+    --avoid-existing
+                   Skip bumped versions that are already tagged (v1.4.0-rc.2 or 1.4.0~rc.2) and
+                   repeat the bump until the version is free (1.4.0~rc.2 -> 1.4.0~rc.3)
+    --style style  Output style of the bumped version:
+                   canonical  1.2.3~rc.1 (default)
+                   git        v1.2.3-rc.1, ready for git tag (api/v1.2.3-rc.1 with --module)
+                   prefix     v1.2.3~rc.1
+                   hyphen     1.2.3-rc.1
+                   input      the style of the bumped version ('v' prefix, '-' delimiter)
+
 Bump types:
     major      Increment major version and reset minor/patch (e.g., 1.2.3 -> 2.0.0)
     minor      Increment minor version and reset patch (e.g., 1.2.3 -> 1.3.0)
     patch      Increment patch version (e.g., 1.2.3 -> 1.2.4)
-    pre        Convert to prerelease with pre.1 or increment prerelease identifier
-    alpha      Convert to prerelease with alpha.1 or increment prerelease identifier
-    beta       Convert to prerelease with beta.1 or increment prerelease identifier
-    rc         Convert to prerelease with rc.1 or increment prerelease identifier
+    pre        Convert to prerelease with pre.1, increment a pre prerelease or promote a lower one
+    alpha      Convert to prerelease with alpha.1 or increment an alpha prerelease
+    beta       Convert to prerelease with beta.1, increment a beta prerelease or promote an alpha one
+    rc         Convert to prerelease with rc.1, increment an rc prerelease or promote a lower one
+               (prereleases move forward alpha -> beta -> pre -> rc, e.g. 1.2.3~alpha.4 beta -> 1.2.3~beta.1)
+    prerelease Bump to the default prerelease label, alpha unless set by version.bump.prerelease
+               in .project.yml (e.g., 1.2.3 minor+prerelease -> 1.3.0~alpha.1)
+    release    Finalize a prerelease to its release, alias finalize (e.g., 1.2.3~rc.2 -> 1.2.3)
+    auto       Choose major, minor or patch from the Conventional Commits since the current
+               version tag: breaking change (feat!:, BREAKING CHANGE:) major, feat minor,
+               otherwise patch; --debug lists the commits that decided it
+    branch     Convert to the intermediate version of the current git branch, numbered after the
+               existing tags of the branch (e.g., feature/PAY-123-refund: 1.2.3 -> 1.2.3_pay_123_refund.1)
     fix        Convert to postrelease with fix.1 or increment postrelease identifier
     next       Convert to postrelease with next.1 or increment postrelease identifier
     post       Convert to postrelease with post.1 or increment postrelease identifier
     feat       Convert to intermediate with feat.1 or increment intermediate identifier
     smart      Intelligent bump based on current version type (default)
+    core+label Bump the core version and start a prerelease of it (e.g., 1.2.3 minor+alpha -> 1.3.0~alpha.1),
+               core is major, minor, patch, revision or calendar, label a prerelease label
+    calendar   Advance a calendar version (version.calver in .project.yml) to the current date,
+               SOURCE_DATE_EPOCH when set; the MICRO segment is incremented on the same date
+    revision   Increment the fourth segment of an N-part version (version.segments in .project.yml),
+               alias build (e.g., 1.2.3 -> 1.2.3.1, 1.2.3.4 -> 1.2.3.5)
+    <label>    Custom prerelease or postrelease label from .project.yml (version.labels),
+               use label:<name> for a label named like a bump type (e.g. label:patch)
 
 Examples:
     version bump                    # Smart bump current git version
@@ -178,8 +316,20 @@ Examples:
     version bump 1.2.3 major       # Major bump version 1.2.3
     version bump 1.2.3 alpha       # Convert to prerelease with alpha.1
     version bump 1.2.3~alpha.1     # Smart bump prerelease version
+    version bump 1.2.3~alpha.4 rc  # Promote to release candidate (1.2.3~rc.1)
+    version bump 1.2.3~rc.2 release  # Finalize to 1.2.3
+    version bump auto              # Bump the git version as its commits ask for
     version bump 1.2.3 fix         # Convert to postrelease with fix.1
     version bump 1.2.3 feat        # Convert to intermediate with feat.1
+    version bump branch            # Next intermediate version of the current branch
+    version bump rc --avoid-existing  # Next release candidate that is not tagged yet
+    version bump 1.2.3 minor+alpha # New minor version as a prerelease (1.3.0~alpha.1)
+    version bump 1.2.3 major --pre rc  # New major version as a release candidate (2.0.0~rc.1)
+    version bump 1.2.3+build.42    # Smart bump keeping build metadata (1.2.4+build.42)
+    version bump 1.2.3+build.42 patch --drop-build  # Drop build metadata (1.2.4)
+    version bump --module api minor # Minor bump of the latest api/v* tag
+    version bump v1.2.3-rc.1 --style input  # Keep the input style (v1.2.3-rc.2)
+    git tag "$(version bump --style git minor)"  # Tag the next minor version
 
 Build Script Usage:
     # Bump current git version and capture result (already silent by default)
@@ -209,14 +359,21 @@ Build Script Usage:
     scripts/version bump smart      # Intelligent bump
 
 Smart bump behavior:
-    - Release versions: increment patch
+    - Release versions: increment patch (advance the calendar date for calendar versions,
+      increment the last segment for N-part versions)
     - Prerelease versions: increment prerelease identifier
     - Postrelease versions: increment postrelease identifier
     - Intermediate versions: increment intermediate identifier
+
+Bump policy (version.bump in .project.yml):
+    smart        Bump of a smart bump per version type, e.g. release: minor, prerelease: release
+    prerelease   Default prerelease label of the prerelease bump type (default alpha)
+    postrelease  false rejects bumps to postrelease versions (default true)
+    start        Number of new identifiers, e.g. 0 for alpha.0 (default 1)
 `)
 }
 
 // getBumpCommandHelp returns help text for the bump command
 func getBumpCommandHelp() string {
-	return `bump [version] [type]    bump version with specified type (smart, major, minor, patch, pre, alpha, beta, rc, fix, next, post, feat)`
+	return `bump [version] [type]    bump version with specified type (smart, major, minor, patch, pre, alpha, beta, rc, fix, next, post, feat, calendar, revision)`
 }
\ No newline at end of file
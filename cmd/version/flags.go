@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"io"
+)
+
+// newCommandFlagSet creates a flag set for command specific options.
+// Errors are returned to the caller instead of being printed by the flag package.
+func newCommandFlagSet(command string) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseCommandFlags parses command options that may be interspersed with positional
+// arguments (e.g. "check 1.2.3 --dialect semver2") and returns the positional arguments.
+// Everything after a "--" terminator is treated as positional.
+func parseCommandFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			positional = append(positional, args[1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			args = args[1:]
+			continue
+		}
+
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		// flag stops after a "--" terminator: everything left is positional
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		args = rest
+	}
+	return positional, nil
+}
@@ -4,6 +4,7 @@ import (
     "fmt"
     "os/exec"
     "regexp"
+    "sort"
     "strings"
     
     "github.com/AlexBurnes/version-go/pkg/version"
@@ -19,6 +20,9 @@ const (
 // Global configuration provider
 var configProvider = version.NewConfigProvider()
 
+// Module selected with --module, the zero module uses the repository tags v[0-9]*
+var currentModule version.Module
+
 // Custom error types for git-related issues
 type GitNotFoundError struct{}
 type NotGitRepoError struct{}
@@ -84,7 +88,7 @@ func checkGitTags() error {
         return err
     }
 
-    output, err := runCommand("git", "tag", "-l", "v[0-9]*")
+    output, err := runCommand("git", "tag", "-l", currentModule.TagPattern())
     if err != nil {
         return err
     }
@@ -113,12 +117,12 @@ func getVersion() (string, error) {
         return "", err
     }
 
-    output, err := runGitCommand("describe", "--match", "v[0-9]*", "--abbrev=0", "--tags", "HEAD")
+    output, err := runGitCommand("describe", "--match", currentModule.TagPattern(), "--abbrev=0", "--tags", "HEAD")
     if err != nil {
         return "", err
     }
     
-    versionStr := strings.TrimPrefix(output, "v")
+    versionStr := strings.TrimPrefix(strings.TrimPrefix(output, currentModule.TagPrefix), "v")
     // Convert git tag format using the library
     versionStr = version.ConvertGitTag(versionStr)
     return versionStr, nil
@@ -315,6 +319,107 @@ func getModuleFromCustomConfig(configPath string) (string, error) {
     return moduleName, nil
 }
 
+// loadProjectConfig loads the --config file or .project.yml, nil when there is none
+func loadProjectConfig() (*version.ProjectConfig, error) {
+    if configFile != "" {
+        return version.GetProjectConfigFromFile(configFile)
+    }
+    return configProvider.LoadProjectConfig()
+}
+
+// loadVersionConfig applies the version section of the project configuration:
+// custom labels, label precedence, the calendar versioning scheme, N-part versions
+// and the bump policy
+func loadVersionConfig() error {
+    config, err := loadProjectConfig()
+    if err != nil {
+        printDebug("No version configuration loaded, failed to load project configuration: %v", err)
+        return nil
+    }
+    if err := loadCalVer(config); err != nil {
+        return err
+    }
+    if err := loadSegments(config); err != nil {
+        return err
+    }
+    if err := loadLabels(config); err != nil {
+        return err
+    }
+    return loadBumpPolicy(config)
+}
+
+// loadCalVer sets the calendar versioning scheme declared in the project configuration
+func loadCalVer(config *version.ProjectConfig) error {
+    if config == nil {
+        return nil
+    }
+    calver, err := config.CalVer()
+    if err != nil {
+        return fmt.Errorf("invalid version scheme in project configuration: %v", err)
+    }
+    if calver != nil {
+        version.SetCalVer(calver)
+        printDebug("Using calendar versioning scheme %s", calver)
+    }
+    return nil
+}
+
+// loadSegments enables the N-part numeric versions declared in the project configuration
+func loadSegments(config *version.ProjectConfig) error {
+    if config == nil {
+        return nil
+    }
+    n, err := config.Segments()
+    if err != nil {
+        return fmt.Errorf("invalid version scheme in project configuration: %v", err)
+    }
+    if n > 0 {
+        if err := version.SetSegments(n); err != nil {
+            return err
+        }
+        printDebug("Using versions with up to %d numeric segments", n)
+    }
+    return nil
+}
+
+// loadLabels registers the custom version labels and label precedence declared in the project configuration
+func loadLabels(config *version.ProjectConfig) error {
+    precedence := config != nil && (len(config.Version.Precedence.Prerelease) > 0 || len(config.Version.Precedence.Postrelease) > 0)
+    if config == nil || (len(config.Version.Labels) == 0 && !precedence) {
+        return nil
+    }
+    
+    registry, err := config.LabelRegistry()
+    if err != nil {
+        return fmt.Errorf("invalid version labels in project configuration: %v", err)
+    }
+    version.SetLabelRegistry(registry)
+    printDebug("Loaded %d custom version labels from project configuration", len(config.Version.Labels))
+    if precedence {
+        printDebug("Label precedence: prerelease %s, postrelease %s",
+            strings.Join(registry.Precedence(version.TypePrerelease), " < "),
+            strings.Join(registry.Precedence(version.TypePostrelease), " < "))
+    }
+    return nil
+}
+
+// loadBumpPolicy sets the bump policy declared in the project configuration,
+// after loadLabels so that the policy can use custom labels
+func loadBumpPolicy(config *version.ProjectConfig) error {
+    if config == nil {
+        return nil
+    }
+    policy, err := config.BumpPolicy()
+    if err != nil {
+        return fmt.Errorf("invalid bump policy in project configuration: %v", err)
+    }
+    if err := version.SetBumpPolicy(policy); err != nil {
+        return err
+    }
+    printDebug("Using bump policy: %s", policy)
+    return nil
+}
+
 // getModuleFromGit returns module name from git remote
 func getModuleFromGit() (string, error) {
     printDebug("Using module name from git remote")
@@ -503,16 +608,27 @@ func getFull() (string, error) {
 
 // getGitTags returns all version tags from git repository
 func getGitTags() ([]string, error) {
-    output, err := runGitCommand("tag", "-l", "v[0-9]*")
+    output, err := runGitCommand("tag", "-l", currentModule.TagPattern())
     if err != nil {
         return nil, err
     }
     
+    return moduleTags(output), nil
+}
+
+// moduleTags splits git tag output into the version tags of the current module
+// without the module tag prefix (api/v1.2.3 -> v1.2.3)
+func moduleTags(output string) []string {
+    tags := []string{}
     if output == "" {
-        return []string{}, nil
+        return tags
     }
-    
-    return strings.Split(strings.TrimSpace(output), "\n"), nil
+    for _, tag := range strings.Split(strings.TrimSpace(output), "\n") {
+        if versionTag, ok := currentModule.TrimTag(tag); ok {
+            tags = append(tags, versionTag)
+        }
+    }
+    return tags
 }
 
 // getGitTagsOnBranch returns version tags that are reachable from the current branch
@@ -525,16 +641,97 @@ func getGitTagsOnBranch() ([]string, error) {
     branch = strings.TrimSpace(branch)
     
     // Get tags reachable from current branch
-    output, err := runGitCommand("tag", "-l", "v[0-9]*", "--merged", branch)
+    output, err := runGitCommand("tag", "-l", currentModule.TagPattern(), "--merged", branch)
     if err != nil {
         return nil, err
     }
     
-    if output == "" {
-        return []string{}, nil
-    }
+    return moduleTags(output), nil
+}
+
+// parseModuleArgs parses the --module option of a command and returns the remaining arguments
+func parseModuleArgs(command string, args []string) ([]string, error) {
+    fs := newCommandFlagSet(command)
+    module := fs.String("module", "", "module from .project.yml whose tags are used")
     
-    return strings.Split(strings.TrimSpace(output), "\n"), nil
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return nil, err
+    }
+    if err := selectModule(*module); err != nil {
+        return nil, err
+    }
+    return rest, nil
+}
+
+// selectModule makes the git tag commands use the tags of a module declared in
+// the project configuration, the repository tags when name is empty
+func selectModule(name string) error {
+    if name == "" {
+        return nil
+    }
+    config, err := loadProjectConfig()
+    if err != nil {
+        return fmt.Errorf("failed to load project configuration for module %s: %v", name, err)
+    }
+    if config == nil {
+        return fmt.Errorf("unknown module '%s': no .project.yml found", name)
+    }
+    module, err := config.Module(name)
+    if err != nil {
+        return err
+    }
+    currentModule = module
+    printDebug("Using module %s with tags %s", module.Name, module.TagPattern())
+    return nil
+}
+
+// parseLatestArgs parses the latest command options
+func parseLatestArgs(args []string) (string, version.LatestOptions, error) {
+    var opts version.LatestOptions
+
+    fs := newCommandFlagSet("latest")
+    constraint := fs.String("constraint", "*", "version constraint expression the tag must satisfy")
+    exclude := fs.String("exclude", "", "comma separated version types to skip (prerelease, postrelease, intermediate)")
+
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return "", opts, err
+    }
+    if len(rest) > 0 {
+        return "", opts, fmt.Errorf("unexpected argument '%s' - usage: latest [--constraint expr] [--exclude types]", rest[0])
+    }
+
+    if *exclude != "" {
+        for _, name := range strings.Split(*exclude, ",") {
+            t, err := version.ParseType(strings.TrimSpace(name))
+            if err != nil {
+                return "", opts, err
+            }
+            opts.ExcludeTypes = append(opts.ExcludeTypes, t)
+        }
+    }
+    return *constraint, opts, nil
+}
+
+// getLatest returns the highest version tag that satisfies the constraint
+func getLatest(constraintStr string, opts version.LatestOptions) (string, error) {
+    constraint, err := version.ParseConstraint(constraintStr)
+    if err != nil {
+        return "", err
+    }
+
+    if err := checkGitTags(); err != nil {
+        return "", err
+    }
+
+    tags, err := getGitTags()
+    if err != nil {
+        return "", fmt.Errorf("failed to get git tags: %v", err)
+    }
+    printDebug("Found %d version tags, constraint '%s'", len(tags), constraint)
+
+    return version.FindLatest(tags, constraint, opts)
 }
 
 // checkGreatest checks if the given version is the greatest among tags on current branch
@@ -555,10 +752,13 @@ func checkGreatest(versionStr string) (string, error) {
         return fmt.Sprintf("Version %s is the greatest (no other tags found on current branch)", versionStr), nil
     }
 
-    // Find all valid version tags and track the greatest ones
+    // Find all valid version tags and track the greatest one, tags equal to an
+    // already seen version (v1.2.3-rc.1 and 1.2.3~rc.1) are counted once
     var validTags []string
+    var validVers []*version.Version
     var greatestTags []string
     var greatestVer *version.Version
+    seen := make(map[string]struct{}, len(tags))
 
     for _, tag := range tags {
         // Try to parse the tag (skip invalid ones)
@@ -567,20 +767,25 @@ func checkGreatest(versionStr string) (string, error) {
             printDebug("Skipping invalid tag: %s", tag)
             continue
         }
+        canonical := tagVer.Canonical()
+        if _, ok := seen[canonical]; ok {
+            printDebug("Skipping duplicate tag: %s", tag)
+            continue
+        }
+        seen[canonical] = struct{}{}
 
         validTags = append(validTags, tag)
-        
+        validVers = append(validVers, tagVer)
+
         // Skip current version when tracking greatest version
-        if tag == versionStr || tag == "v"+versionStr {
+        if version.Equal(tagVer, currentVer) {
             continue
         }
-        
+
         // Track the greatest version
         if greatestVer == nil || version.Compare(tagVer, greatestVer) > 0 {
             greatestVer = tagVer
             greatestTags = []string{tag}
-        } else if version.Compare(tagVer, greatestVer) == 0 {
-            greatestTags = append(greatestTags, tag)
         }
     }
 
@@ -590,33 +795,24 @@ func checkGreatest(versionStr string) (string, error) {
         errorMsg.WriteString(fmt.Sprintf("version %s is not the greatest among tags on current branch", versionStr))
         errorMsg.WriteString(fmt.Sprintf("\nFound %d valid tags on current branch:", len(validTags)))
         
-        // Sort tags for better display
-        sortedTags := make([]string, len(validTags))
-        copy(sortedTags, validTags)
-        
-        // Sort tags by version (we'll use a simple string sort for display)
-        for i := 0; i < len(sortedTags); i++ {
-            for j := i + 1; j < len(sortedTags); j++ {
-                tagI, errI := version.Parse(sortedTags[i])
-                tagJ, errJ := version.Parse(sortedTags[j])
-                if errI == nil && errJ == nil && version.Compare(tagI, tagJ) > 0 {
-                    sortedTags[i], sortedTags[j] = sortedTags[j], sortedTags[i]
-                }
-            }
+        // Sort tags by version for better display, keeping the tags as written
+        order := make([]int, len(validTags))
+        for i := range order {
+            order[i] = i
         }
+        sort.SliceStable(order, func(i, j int) bool {
+            return version.Compare(validVers[order[i]], validVers[order[j]]) < 0
+        })
         
         // Create concise color-coded tag list
         var lowerTags []string
         var currentTag string
         var greaterTags []string
         
-        for _, tag := range sortedTags {
-            tagVer, err := version.Parse(tag)
-            if err != nil {
-                continue
-            }
+        for _, i := range order {
+            tag, tagVer := validTags[i], validVers[i]
             
-            if tag == versionStr || tag == "v"+versionStr {
+            if version.Equal(tagVer, currentVer) {
                 // Current version - white/normal
                 currentTag = tag
             } else if version.Compare(tagVer, currentVer) > 0 {
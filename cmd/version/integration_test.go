@@ -393,4 +393,1186 @@ func TestCheckGreatestWithCurrentVersion(t *testing.T) {
             t.Errorf("Expected exit code 0 or 1 for check-greatest with old version, got %d", exitCode)
         }
     })
-}
\ No newline at end of file
+}
+func TestCheckDialect(t *testing.T) {
+    tests := []struct {
+        args     []string
+        hasError bool
+    }{
+        {[]string{"check", "--dialect", "semver2", "1.2.3-rc.1+build.5"}, false},
+        {[]string{"check", "1.0.0-alpha.beta", "--dialect=semver2"}, false},
+        {[]string{"check", "--dialect", "semver2", "1.2.3~rc.1"}, true},
+        {[]string{"check", "--dialect", "semver2", "v1.2.3"}, true},
+        {[]string{"check", "--dialect", "semver2", "1.2.3-01"}, true},
+        {[]string{"check", "--dialect", "extended", "1.2.3~rc.1"}, false},
+        {[]string{"check", "--dialect", "unknown", "1.2.3"}, true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.CombinedOutput()
+            if test.hasError && err == nil {
+                t.Errorf("Expected error for %v, but got none", test.args)
+            }
+            if !test.hasError && err != nil {
+                t.Errorf("Unexpected error for %v: %v. Output: %s", test.args, err, string(output))
+            }
+        })
+    }
+}
+
+func TestSatisfies(t *testing.T) {
+    tests := []struct {
+        args     []string
+        hasError bool
+    }{
+        {[]string{"satisfies", "1.5.0", ">=1.2.0 <2.0.0"}, false},
+        {[]string{"satisfies", "1.5.0", ">=1.2.0", "<2.0.0"}, false},
+        {[]string{"satisfies", "2.0.0", "^1.2.3"}, true},
+        {[]string{"satisfies", "1.4.2.fix.1", "1.4.x type=postrelease"}, false},
+        {[]string{"satisfies", "1.4.2_feat", "1.4.x type!=intermediate"}, true},
+        {[]string{"satisfies", "1.3.0", "1.2.3 || 1.3.0"}, false},
+        {[]string{"satisfies", "1.2.3", ">>1.2"}, true},
+        {[]string{"satisfies", "1.2.3"}, true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.CombinedOutput()
+            if test.hasError && err == nil {
+                t.Errorf("Expected error for %v, but got none", test.args)
+            }
+            if !test.hasError && err != nil {
+                t.Errorf("Unexpected error for %v: %v. Output: %s", test.args, err, string(output))
+            }
+        })
+    }
+}
+
+func TestLatest(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    // Build the binary and run it inside a temporary repository with known tags
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    setup := [][]string{
+        {"init", "-q", repo},
+        {"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+    }
+    for _, tag := range []string{"v1.4.0", "v1.4.2", "v1.4.3-rc.1", "v1.4.2.fix.1", "v1.4.2_feat.1", "v1.5.0"} {
+        setup = append(setup, []string{"-C", repo, "tag", tag})
+    }
+    for _, args := range setup {
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"latest"}, "v1.5.0", false},
+        {[]string{"latest", "--constraint", "1.4.x"}, "v1.4.3-rc.1", false},
+        {[]string{"latest", "--constraint", "^1.4", "--exclude", "prerelease"}, "v1.5.0", false},
+        {[]string{"latest", "--constraint=1.4.x", "--exclude=prerelease,intermediate"}, "v1.4.2.fix.1", false},
+        {[]string{"latest", "--constraint", "1.4.x type=release"}, "v1.4.2", false},
+        {[]string{"latest", "--constraint", "2.x"}, "", true},
+        {[]string{"latest", "--exclude", "unknown"}, "", true},
+        {[]string{"latest", "1.4.x"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = repo
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestConvert(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"convert", "1.2.3~rc.1", "--to", "deb"}, "1.2.3~rc.1", false},
+        {[]string{"convert", "--to=rpm", "1.2.3.fix.2"}, "1.2.3^fix^2", false},
+        {[]string{"convert", "v1.2.3-rc.1", "--to", "pep440"}, "1.2.3rc1", false},
+        {[]string{"convert", "1.2.3.fix.2", "--to", "maven"}, "1.2.3-fix-2", false},
+        {[]string{"convert", "1.2.3~rc.1+build.5", "--to", "nuget"}, "1.2.3-rc.1+build.5", false},
+        {[]string{"convert", "1.2.3_feat.1", "--to", "npm"}, "", true},
+        {[]string{"convert", "1.2.3", "--to", "gem"}, "", true},
+        {[]string{"convert", "1.2.3"}, "", true},
+        {[]string{"convert", "invalid", "--to", "deb"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestCompare(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"compare", "1.2.3", "1.2.4"}, "-1", false},
+        {[]string{"compare", "1.2.3.fix.1", "1.2.3"}, "1", false},
+        {[]string{"compare", "v1.2.3-rc.1", "1.2.3~rc.1"}, "0", false},
+        {[]string{"compare", "--scheme", "semver2", "1.0.0-alpha.1", "1.0.0-alpha.beta"}, "-1", false},
+        {[]string{"compare", "--scheme", "deb", "1:2.0-1", "2.1-3"}, "1", false},
+        {[]string{"compare", "--scheme=deb", "1.0~rc1", "1.0"}, "-1", false},
+        {[]string{"compare", "1.0^git1", "1.0", "--scheme", "rpm"}, "1", false},
+        {[]string{"compare", "--scheme", "rpm", "1.0-1", "1.0"}, "0", false},
+        {[]string{"compare", "--scheme", "deb", "a1.0", "1.0"}, "", true},
+        {[]string{"compare", "--scheme", "gem", "1.0", "1.0"}, "", true},
+        {[]string{"compare", "1.2.3"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestDiff(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"diff", "1.2.3", "1.3.0"}, "1.2.3 -> 1.3.0: minor +1 up, successor by minor bump", false},
+        {[]string{"diff", "1.2.3", "1.4.0"}, "1.2.3 -> 1.4.0: minor +2 up", false},
+        {[]string{"diff", "1.2.3", "1.2.3-rc.1"}, "1.2.3 -> 1.2.3~rc.1: type release -> prerelease down", false},
+        {[]string{"diff", "--json", "1.2.3", "1.4.0"}, `{"from":"1.2.3","to":"1.4.0","change":"minor","direction":"up","delta":2,"from_type":"release","to_type":"release","successor":false}`, false},
+        {[]string{"diff", "1.2.3.fix.1", "1.2.3.fix.2", "--json"}, `{"from":"1.2.3.fix.1","to":"1.2.3.fix.2","change":"identifier","direction":"up","delta":0,"from_type":"postrelease","to_type":"postrelease","successor":true,"bump":"smart"}`, false},
+        {[]string{"diff", "1.2.3"}, "", true},
+        {[]string{"diff", "1.2.3", "1.2"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestParseErrorPosition(t *testing.T) {
+    cmd := exec.Command("go", "run", ".", "--no-color", "check", "1.2.3-RC1")
+    cmd.Dir = "."
+
+    output, err := cmd.CombinedOutput()
+    if err == nil {
+        t.Fatalf("Expected error for 1.2.3-RC1, but got none")
+    }
+
+    expected := []string{
+        "at offset 6 (<prerelease-type>)",
+        "did you mean 1.2.3~rc.1?",
+        "    1.2.3-RC1\n          ^\n",
+    }
+    for _, e := range expected {
+        if !strings.Contains(string(output), e) {
+            t.Errorf("Expected output to contain %q, got: %s", e, string(output))
+        }
+    }
+}
+
+func TestCustomLabels(t *testing.T) {
+    // Build the binary and run it inside a directory with custom labels in .project.yml
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    project := dir + "/project"
+    invalid := dir + "/invalid"
+    precedence := dir + "/precedence"
+    for path, section := range map[string]string{
+        project:    "  labels:\n    - name: dev\n      type: prerelease\n      rank: 50\n    - name: hotfix\n      type: postrelease\n      rank: 150\n",
+        invalid:    "  labels:\n    - name: Dev\n      type: prerelease\n",
+        precedence: "  precedence:\n    prerelease: [alpha, beta, rc, pre]\n",
+    } {
+        if err := os.MkdirAll(path, 0755); err != nil {
+            t.Fatalf("Failed to create directory: %v", err)
+        }
+        content := "project:\n  name: test\n  modules: [test]\nversion:\n" + section
+        if err := os.WriteFile(path+"/.project.yml", []byte(content), 0644); err != nil {
+            t.Fatalf("Failed to write .project.yml: %v", err)
+        }
+    }
+
+    tests := []struct {
+        dir      string
+        args     []string
+        stdin    string
+        expected string
+        hasError bool
+    }{
+        {project, []string{"check", "1.2.3~dev.1"}, "", "", false},
+        {project, []string{"type", "1.2.3.hotfix.1"}, "", "postrelease", false},
+        {project, []string{"bump", "1.2.3", "dev"}, "", "1.2.3~dev.1", false},
+        {project, []string{"sort"}, "1.2.3~alpha.1 1.2.3~dev.2 1.2.3.next 1.2.3.hotfix", "1.2.3~dev.2\n1.2.3~alpha.1\n1.2.3.hotfix\n1.2.3.next", false},
+        {project, []string{"check", "1.2.3~nightly"}, "", "", true},
+        {dir, []string{"check", "1.2.3~dev.1"}, "", "", true},
+        {invalid, []string{"check", "1.2.3"}, "", "", true},
+        {precedence, []string{"sort"}, "1.2.3~pre.1 1.2.3~rc.1 1.2.3~beta.1", "1.2.3~beta.1\n1.2.3~rc.1\n1.2.3~pre.1", false},
+        {dir, []string{"sort"}, "1.2.3~pre.1 1.2.3~rc.1 1.2.3~beta.1", "1.2.3~beta.1\n1.2.3~pre.1\n1.2.3~rc.1", false},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = test.dir
+            cmd.Stdin = strings.NewReader(test.stdin)
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestCalVer(t *testing.T) {
+    // Build the binary and run it inside a directory with a calendar versioning scheme in .project.yml
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+    content := "project:\n  name: test\n  modules: [test]\nversion:\n  calver: YY.0M.MICRO\n"
+    if err := os.WriteFile(dir+"/.project.yml", []byte(content), 0644); err != nil {
+        t.Fatalf("Failed to write .project.yml: %v", err)
+    }
+
+    tests := []struct {
+        args     []string
+        stdin    string
+        expected string
+        hasError bool
+    }{
+        {[]string{"check", "24.05.1"}, "", "", false},
+        {[]string{"check", "24.13.1"}, "", "", true},
+        {[]string{"check", "1.2.3"}, "", "", true},
+        {[]string{"type", "24.05.1-rc.1"}, "", "prerelease", false},
+        {[]string{"bump", "24.05.3"}, "", "24.05.4", false},
+        {[]string{"bump", "24.04.3", "calendar"}, "", "24.05.0", false},
+        {[]string{"bump", "24.05.3", "rc"}, "", "24.05.3~rc.1", false},
+        {[]string{"bump", "24.05.3", "minor"}, "", "", true},
+        {[]string{"sort"}, "24.10.0 24.05.1 23.12.4", "23.12.4\n24.05.1\n24.10.0", false},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = dir
+            cmd.Stdin = strings.NewReader(test.stdin)
+            cmd.Env = append(os.Environ(), "SOURCE_DATE_EPOCH=1715904000") // 2024-05-17
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestSegments(t *testing.T) {
+    // Build the binary and run it inside a directory with four-part versions enabled in .project.yml
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+    content := "project:\n  name: test\n  modules: [test]\nversion:\n  segments: 4\n"
+    if err := os.WriteFile(dir+"/.project.yml", []byte(content), 0644); err != nil {
+        t.Fatalf("Failed to write .project.yml: %v", err)
+    }
+
+    tests := []struct {
+        args     []string
+        stdin    string
+        expected string
+        hasError bool
+    }{
+        {[]string{"check", "1.2.3.4"}, "", "", false},
+        {[]string{"check", "1.2.3.4.5"}, "", "", true},
+        {[]string{"bump", "1.2.3.4"}, "", "1.2.3.5", false},
+        {[]string{"bump", "1.2.3", "revision"}, "", "1.2.3.1", false},
+        {[]string{"bump", "1.2.3.4", "build"}, "", "1.2.3.5", false},
+        {[]string{"bump", "1.2.3.4", "minor"}, "", "1.3.0", false},
+        {[]string{"convert", "1.2.3.4", "--to", "windows"}, "", "1,2,3,4", false},
+        {[]string{"convert", "1.2.3.4", "--to", "deb"}, "", "", true},
+        {[]string{"sort"}, "1.2.3.10 1.2.3.9 1.2.3", "1.2.3\n1.2.3.9\n1.2.3.10", false},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = dir
+            cmd.Stdin = strings.NewReader(test.stdin)
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestBumpPolicy(t *testing.T) {
+    // Build the binary and run it inside a directory with a bump policy in .project.yml
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    policy := dir + "/policy"
+    invalid := dir + "/invalid"
+    for path, section := range map[string]string{
+        policy:  "  labels:\n    - name: dev\n      type: prerelease\n      rank: 50\n  bump:\n    smart:\n      release: minor+prerelease\n      prerelease: release\n    prerelease: dev\n    postrelease: false\n    start: 0\n",
+        invalid: "  bump:\n    prerelease: fix\n",
+    } {
+        if err := os.MkdirAll(path, 0755); err != nil {
+            t.Fatalf("Failed to create directory: %v", err)
+        }
+        content := "project:\n  name: test\n  modules: [test]\nversion:\n" + section
+        if err := os.WriteFile(path+"/.project.yml", []byte(content), 0644); err != nil {
+            t.Fatalf("Failed to write .project.yml: %v", err)
+        }
+    }
+
+    tests := []struct {
+        dir      string
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {policy, []string{"bump", "1.2.3"}, "1.3.0~dev.0", false},
+        {policy, []string{"bump", "1.3.0~dev.0"}, "1.3.0", false},
+        {policy, []string{"bump", "1.2.3", "prerelease"}, "1.2.3~dev.0", false},
+        {policy, []string{"bump", "1.2.3", "major", "--pre", "prerelease"}, "2.0.0~dev.0", false},
+        {policy, []string{"bump", "1.2.3", "rc"}, "1.2.3~rc.0", false},
+        {policy, []string{"bump", "1.2.3", "fix"}, "", true},
+        {policy, []string{"bump", "1.2.3_feat.1"}, "1.2.3_feat.2", false},
+        {dir, []string{"bump", "1.2.3"}, "1.2.4", false},
+        {dir, []string{"bump", "1.2.3", "prerelease"}, "1.2.3~alpha.1", false},
+        {invalid, []string{"bump", "1.2.3"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = test.dir
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestExtract(t *testing.T) {
+    tests := []struct {
+        args     []string
+        stdin    string
+        expected string
+        hasError bool
+    }{
+        {[]string{"extract", "myapp-1.4.2-linux-amd64.tar.gz"}, "", "1.4.2", false},
+        {[]string{"extract", "myapp-v1.4.2-rc.1-linux.zip"}, "", "v1.4.2-rc.1", false},
+        {[]string{"extract"}, "cmake version 3.28.1\n", "3.28.1", false},
+        {[]string{"extract", "--all"}, "go1.22.3 and go1.21.10", "1.22.3\n1.21.10", false},
+        {[]string{"extract", "--json", "go1.22.3"}, "", `{"text":"1.22.3","version":"1.22.3","type":"release","offset":2}`, false},
+        {[]string{"extract", "--all", "--json", "1.2.3-rc.1"}, "", `[{"text":"1.2.3-rc.1","version":"1.2.3~rc.1","type":"prerelease","offset":0}]`, false},
+        {[]string{"extract", "Python 3.12"}, "", "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            cmd.Stdin = strings.NewReader(test.stdin)
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestModules(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    // Build the binary and run it inside a temporary monorepo with per-module tags
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    setup := [][]string{
+        {"init", "-q", repo},
+        {"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+    }
+    // Newer tags of each module on a second commit, git describe picks them
+    for i, tags := range [][]string{{"v2.0.0", "api/v1.2.3", "web/v0.9.0"}, {"api/v1.3.0-rc.1", "web/v0.10.0"}} {
+        if i > 0 {
+            setup = append(setup, []string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "next"})
+        }
+        for _, tag := range tags {
+            setup = append(setup, []string{"-C", repo, "tag", tag})
+        }
+    }
+    for _, args := range setup {
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+    content := "project:\n  name: mono\n  modules:\n    - root\n    - name: api\n      tag_prefix: api/\n    - name: web\n      tag_prefix: web/\n"
+    if err := os.WriteFile(repo+"/.project.yml", []byte(content), 0644); err != nil {
+        t.Fatalf("Failed to write .project.yml: %v", err)
+    }
+
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"version"}, "2.0.0", false},
+        {[]string{"version", "--module", "api"}, "1.3.0~rc.1", false},
+        {[]string{"version", "--module=web"}, "0.10.0", false},
+        {[]string{"version", "--module", "root"}, "2.0.0", false},
+        {[]string{"type", "--module", "api"}, "prerelease", false},
+        {[]string{"bump", "--module", "web", "minor"}, "0.11.0", false},
+        {[]string{"bump", "--module", "api"}, "1.3.0~rc.2", false},
+        {[]string{"bump", "--module", "api", "--style", "git"}, "api/v1.3.0-rc.2", false},
+        {[]string{"check-greatest", "--module", "web", "0.10.0"}, "Version 0.10.0 is the greatest among tags on current branch", false},
+        {[]string{"check-greatest", "--module", "web", "0.9.0"}, "", true},
+        {[]string{"modules"}, "root\napi\nweb", false},
+        {[]string{"version", "--module", "unknown"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = repo
+
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestCanonical(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"canonical", "v1.2.3-rc.01+build.5"}, "1.2.3~rc.1", false},
+        {[]string{"canonical", "01.2.3.fix.1"}, "1.2.3.fix.1", false},
+        {[]string{"canonical", "1.2"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+
+    cmd := exec.Command("go", "run", ".", "sort")
+    cmd.Stdin = strings.NewReader("1.2.4 v1.2.3 1.2.3-rc.1 1.2.3 1.2.3~rc.01")
+    output, err := cmd.Output()
+    if err != nil {
+        t.Fatalf("Unexpected error for sort: %v", err)
+    }
+    if strings.TrimSpace(string(output)) != "1.2.3~rc.1\nv1.2.3\n1.2.4" {
+        t.Errorf("sort = %s, want equal versions once", strings.TrimSpace(string(output)))
+    }
+}
+
+func TestCheckGreatestEqualTags(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    setup := [][]string{
+        {"init", "-q", repo},
+        {"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+        {"-C", repo, "tag", "v1.2.2"},
+        {"-C", repo, "tag", "v1.2.3-rc.1"},
+        {"-C", repo, "tag", "v1.2.3-rc.01"},
+    }
+    for _, args := range setup {
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+
+    // The tags use '-' and a leading zero, the argument the canonical form
+    cmd := exec.Command(binary, "check-greatest", "1.2.3~rc.1")
+    cmd.Dir = repo
+    output, err := cmd.CombinedOutput()
+    if err != nil {
+        t.Fatalf("check-greatest 1.2.3~rc.1 failed: %v. Output: %s", err, string(output))
+    }
+
+    if err := exec.Command("git", "-C", repo, "tag", "v1.3.0").Run(); err != nil {
+        t.Fatalf("git tag failed: %v", err)
+    }
+    cmd = exec.Command(binary, "check-greatest", "1.2.3~rc.1")
+    cmd.Dir = repo
+    output, err = cmd.CombinedOutput()
+    if err == nil {
+        t.Fatalf("Expected check-greatest 1.2.3~rc.1 to fail, got %s", string(output))
+    }
+    if !strings.Contains(string(output), "Found 3 valid tags") || !strings.Contains(string(output), colorReset+", v1.2.3-rc.") {
+        t.Errorf("check-greatest does not count equal tags once or mark the current tag: %s", string(output))
+    }
+}
+
+
+func TestBumpStyle(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"bump", "v1.2.3-rc.1"}, "1.2.3~rc.2", false},
+        {[]string{"bump", "1.2.3~rc.1", "--style", "git"}, "v1.2.3-rc.2", false},
+        {[]string{"bump", "--style=input", "v1.2.3-rc.1"}, "v1.2.3-rc.2", false},
+        {[]string{"bump", "v1.2.3", "minor", "--style", "input"}, "v1.3.0", false},
+        {[]string{"bump", "v1.2.3", "rc", "--style", "hyphen"}, "1.2.3-rc.1", false},
+        {[]string{"bump", "1.2.3", "--style", "debian"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestBumpSpec(t *testing.T) {
+    tests := []struct {
+        args     []string
+        expected string
+        hasError bool
+    }{
+        {[]string{"bump", "1.2.3", "minor+alpha"}, "1.3.0~alpha.1", false},
+        {[]string{"bump", "1.2.3", "major", "--pre", "rc"}, "2.0.0~rc.1", false},
+        {[]string{"bump", "--pre=beta", "v1.2.3", "patch", "--style", "git"}, "v1.2.4-beta.1", false},
+        {[]string{"bump", "1.2.3+build.5", "minor+rc"}, "1.3.0~rc.1+build.5", false},
+        {[]string{"bump", "1.2.3", "minor+fix"}, "", true},
+        {[]string{"bump", "1.2.3", "minor+alpha", "--pre", "rc"}, "", true},
+        {[]string{"bump", "1.2.3", "--pre", "rc"}, "", true},
+        {[]string{"bump", "1.2.3~alpha.4", "beta"}, "1.2.3~beta.1", false},
+        {[]string{"bump", "v1.2.3-rc.2", "finalize", "--style", "input"}, "v1.2.3", false},
+        {[]string{"bump", "1.2.3~rc.1", "alpha"}, "", true},
+        {[]string{"bump", "1.2.3", "release"}, "", true},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args[1:], "_"), func(t *testing.T) {
+            cmd := exec.Command("go", append([]string{"run", "."}, test.args...)...)
+            cmd.Dir = "."
+            
+            output, err := cmd.Output()
+            if test.hasError {
+                if err == nil {
+                    t.Errorf("Expected error for %v, but got %s", test.args, string(output))
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+}
+
+func TestBumpAuto(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    git := func(args ...string) {
+        args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+    if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+        t.Fatalf("git init failed: %v. Output: %s", err, string(output))
+    }
+    git("commit", "-q", "--allow-empty", "-m", "feat: initial")
+    git("tag", "v1.2.3")
+
+    tests := []struct {
+        message  string // commit added before the bump
+        args     []string
+        expected string
+    }{
+        {"chore: ci", []string{"bump", "auto"}, "1.2.4"},
+        {"fix: crash on empty input", []string{"bump", "auto"}, "1.2.4"},
+        {"feat(cli): add auto bump", []string{"bump", "auto", "--style", "git"}, "v1.3.0"},
+        {"refactor!: rename options", []string{"bump", "auto"}, "2.0.0"},
+    }
+
+    for _, test := range tests {
+        t.Run(test.message, func(t *testing.T) {
+            git("commit", "-q", "--allow-empty", "-m", test.message)
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = repo
+
+            output, err := cmd.Output()
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+
+    // Commits before the current version tag do not count
+    git("tag", "v2.0.0")
+    git("commit", "-q", "--allow-empty", "-m", "fix: after release")
+    cmd := exec.Command(binary, "--debug", "bump", "auto")
+    cmd.Dir = repo
+    output, err := cmd.CombinedOutput()
+    if err != nil {
+        t.Fatalf("bump auto failed: %v. Output: %s", err, string(output))
+    }
+    if !strings.Contains(string(output), "2.0.1") || !strings.Contains(string(output), "fix by") || !strings.Contains(string(output), "fix: after release") {
+        t.Errorf("bump auto does not explain the bump from the commits since v2.0.0: %s", string(output))
+    }
+}
+
+func TestBumpBranch(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    git := func(args ...string) {
+        args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+    if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+        t.Fatalf("git init failed: %v. Output: %s", err, string(output))
+    }
+    git("commit", "-q", "--allow-empty", "-m", "initial")
+    git("tag", "v1.2.3")
+    git("checkout", "-q", "-b", "feature/PAY-123-refund")
+    git("commit", "-q", "--allow-empty", "-m", "refund")
+
+    tests := []struct {
+        tag      string // tag of HEAD created before the bump
+        args     []string
+        expected string
+    }{
+        {"", []string{"bump", "branch"}, "1.2.3_pay_123_refund.1"},
+        {"v1.2.3_pay_123_refund.1", []string{"bump", "branch"}, "1.2.3_pay_123_refund.2"},
+        {"", []string{"bump", "1.2.3", "branch"}, "1.2.3_pay_123_refund.2"},
+        {"", []string{"bump", "1.2.3", "branch", "--branch", "bugfix/Login-Timeout"}, "1.2.3_login_timeout.1"},
+        {"", []string{"bump", "1.2.3", "branch", "--style", "git"}, "v1.2.3_pay_123_refund.2"},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            if test.tag != "" {
+                git("commit", "-q", "--allow-empty", "-m", "change")
+                git("tag", test.tag)
+            }
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = repo
+
+            output, err := cmd.Output()
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+
+    // A detached HEAD has no branch
+    git("checkout", "-q", "--detach")
+    cmd := exec.Command(binary, "bump", "branch")
+    cmd.Dir = repo
+    if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "--branch") {
+        t.Errorf("Expected error pointing to --branch for a detached HEAD, got %s", string(output))
+    }
+}
+
+func TestBumpAvoidExisting(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    repo := dir + "/repo"
+    git := func(args ...string) {
+        args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+        if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+    }
+    if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+        t.Fatalf("git init failed: %v. Output: %s", err, string(output))
+    }
+    git("commit", "-q", "--allow-empty", "-m", "initial")
+    git("tag", "v1.4.0-rc.1")
+    // A parallel branch already tagged the next release candidates
+    git("commit", "-q", "--allow-empty", "-m", "parallel")
+    git("tag", "v1.4.0-rc.2")
+    git("tag", "v1.4.0-rc.3")
+    git("tag", "api/v1.4.0-rc.4")
+    git("reset", "-q", "--hard", "HEAD~1")
+
+    tests := []struct {
+        args     []string
+        expected string
+    }{
+        {[]string{"bump", "1.4.0~rc.1", "rc"}, "1.4.0~rc.2"},
+        {[]string{"bump", "1.4.0~rc.1", "rc", "--avoid-existing"}, "1.4.0~rc.4"},
+        {[]string{"bump", "--avoid-existing", "--style", "git"}, "v1.4.0-rc.4"},
+        {[]string{"bump", "1.3.0", "minor+rc", "--avoid-existing"}, "1.4.0~rc.4"},
+        {[]string{"bump", "1.4.0~rc.1", "release", "--avoid-existing"}, "1.4.0"},
+    }
+
+    for _, test := range tests {
+        t.Run(strings.Join(test.args, "_"), func(t *testing.T) {
+            cmd := exec.Command(binary, test.args...)
+            cmd.Dir = repo
+
+            output, err := cmd.Output()
+            if err != nil {
+                t.Fatalf("Unexpected error for %v: %v", test.args, err)
+            }
+            if strings.TrimSpace(string(output)) != test.expected {
+                t.Errorf("%v = %s, want %s", test.args, strings.TrimSpace(string(output)), test.expected)
+            }
+        })
+    }
+
+    // --debug reports the skipped candidates
+    cmd := exec.Command(binary, "--debug", "bump", "1.4.0~rc.1", "rc", "--avoid-existing")
+    cmd.Dir = repo
+    output, err := cmd.CombinedOutput()
+    if err != nil {
+        t.Fatalf("bump --avoid-existing failed: %v. Output: %s", err, string(output))
+    }
+    if !strings.Contains(string(output), "Skipped existing versions: 1.4.0~rc.2, 1.4.0~rc.3") {
+        t.Errorf("bump --avoid-existing does not report the skipped versions: %s", string(output))
+    }
+}
+
+func TestRelease(t *testing.T) {
+    if _, err := exec.LookPath("git"); err != nil {
+        t.Skip("git is not available")
+    }
+
+    dir := t.TempDir()
+    binary := dir + "/version"
+    if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+        t.Fatalf("Failed to build binary: %v. Output: %s", err, string(output))
+    }
+
+    remote := dir + "/remote.git"
+    if output, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+        t.Fatalf("git init failed: %v. Output: %s", err, string(output))
+    }
+
+    // newRepo creates a repository with files committed and tagged
+    newRepo := func(name, tag string, files map[string]string) string {
+        repo := dir + "/" + name
+        if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+            t.Fatalf("git init failed: %v. Output: %s", err, string(output))
+        }
+        for name, content := range files {
+            if err := os.WriteFile(repo+"/"+name, []byte(content), 0644); err != nil {
+                t.Fatalf("Failed to write %s: %v", name, err)
+            }
+        }
+        for _, args := range [][]string{{"add", "."}, {"commit", "-q", "-m", "initial"}, {"tag", tag}} {
+            args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+            if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+                t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+            }
+        }
+        return repo
+    }
+    git := func(repo string, args ...string) string {
+        args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+        output, err := exec.Command("git", args...).CombinedOutput()
+        if err != nil {
+            t.Fatalf("git %v failed: %v. Output: %s", args, err, string(output))
+        }
+        return strings.TrimSpace(string(output))
+    }
+    // release commits with the identity of the environment
+    release := func(repo string, args ...string) (string, error) {
+        cmd := exec.Command(binary, append([]string{"release"}, args...)...)
+        cmd.Dir = repo
+        cmd.Env = append(os.Environ(),
+            "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+            "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+        output, err := cmd.Output()
+        return strings.TrimSpace(string(output)), err
+    }
+    readFile := func(repo, name string) string {
+        content, err := os.ReadFile(repo + "/" + name)
+        if err != nil {
+            t.Fatalf("Failed to read %s: %v", name, err)
+        }
+        return string(content)
+    }
+
+    packageJSON := "{\n  \"version\": \"1.2.3\",\n  \"dependencies\": {\"lib\": \"1.2.3\"}\n}\n"
+    files := map[string]string{
+        ".project.yml": "project:\n  name: test\n  modules: [test]\nversion:\n  release:\n    files:\n      - NOTES.md\n      - path: package.json\n        pattern: '\"version\": \"{version}\"'\n",
+        "NOTES.md":     "Download /download/v1.2.3/app-1.2.3.tar.gz, requires 1.2.0\n",
+        "package.json": packageJSON,
+        "VERSION":      "v1.2.3\n",
+    }
+    repo := newRepo("repo", "v1.2.3", files)
+    git(repo, "remote", "add", "origin", remote)
+    head := git(repo, "rev-parse", "HEAD")
+    branch := git(repo, "symbolic-ref", "--short", "HEAD")
+
+    // release keeps printing the release number
+    cmd := exec.Command(binary, "release")
+    cmd.Dir = repo
+    if output, err := cmd.Output(); err != nil || strings.TrimSpace(string(output)) != "1" {
+        t.Errorf("release = %s, %v, want 1", string(output), err)
+    }
+
+    // A dry run prints the plan with every replaced line and changes nothing
+    output, err := release(repo, "minor", "--dry-run", "--push", "origin")
+    if err != nil {
+        t.Fatalf("release minor --dry-run failed: %v", err)
+    }
+    for _, step := range []string{
+        "release 1.2.3 -> v1.3.0",
+        "write   VERSION (v1.3.0)",
+        "update  NOTES.md (1.2.3 on 1 line)",
+        "        NOTES.md:1: Download /download/v1.2.3/app-1.2.3.tar.gz, requires 1.2.0",
+        "update  package.json (1.2.3 on 1 line)",
+        "        package.json:2: \"version\": \"1.2.3\",",
+        "commit  chore(version): bump to v1.3.0",
+        "tag     v1.3.0 (annotated)",
+        "push    origin " + branch + " v1.3.0",
+    } {
+        if !strings.Contains(output, step) {
+            t.Errorf("release --dry-run plan does not contain %q: %s", step, output)
+        }
+    }
+    if git(repo, "rev-parse", "HEAD") != head || git(repo, "tag", "-l", "v1.3.0") != "" || readFile(repo, "VERSION") != "v1.2.3\n" {
+        t.Errorf("release --dry-run changed the repository")
+    }
+
+    // A pushed release needs a branch, a detached HEAD is refused before any change
+    git(repo, "checkout", "-q", "--detach")
+    if output, err := release(repo, "minor", "--dry-run", "--push", "origin"); err == nil {
+        t.Errorf("Expected error for a pushed release of a detached HEAD, but got %s", output)
+    }
+    if output, err := release(repo, "minor", "--push", "origin"); err == nil {
+        t.Errorf("Expected error for a pushed release of a detached HEAD, but got %s", output)
+    }
+    if git(repo, "rev-parse", "HEAD") != head || git(repo, "tag", "-l", "v1.3.0") != "" || readFile(repo, "VERSION") != "v1.2.3\n" {
+        t.Errorf("release of a detached HEAD changed the repository")
+    }
+    git(repo, "checkout", "-q", branch)
+
+    // A failed push rolls back the commit, the tag and the files
+    if output, err := release(repo, "minor", "--push", "nowhere"); err == nil {
+        t.Errorf("Expected error for a push to a missing remote, but got %s", output)
+    }
+    if git(repo, "rev-parse", "HEAD") != head || git(repo, "tag", "-l", "v1.3.0") != "" || readFile(repo, "VERSION") != "v1.2.3\n" || readFile(repo, "package.json") != packageJSON {
+        t.Errorf("failed release was not rolled back")
+    }
+
+    // A release commits the files, tags the commit and pushes both, the
+    // dependency pin of package.json is left alone by the pattern
+    if output, err := release(repo, "minor", "--push", "origin"); err != nil || output != "v1.3.0" {
+        t.Fatalf("release minor --push origin = %s, %v, want v1.3.0", output, err)
+    }
+    if readFile(repo, "VERSION") != "v1.3.0\n" {
+        t.Errorf("VERSION = %q, want v1.3.0", readFile(repo, "VERSION"))
+    }
+    if expected := strings.Replace(packageJSON, "\"version\": \"1.2.3\"", "\"version\": \"1.3.0\"", 1); readFile(repo, "package.json") != expected {
+        t.Errorf("package.json = %q, want %q", readFile(repo, "package.json"), expected)
+    }
+    if expected := "Download /download/v1.3.0/app-1.3.0.tar.gz, requires 1.2.0\n"; readFile(repo, "NOTES.md") != expected {
+        t.Errorf("NOTES.md = %q, want %q", readFile(repo, "NOTES.md"), expected)
+    }
+    if kind := git(repo, "cat-file", "-t", "v1.3.0"); kind != "tag" {
+        t.Errorf("v1.3.0 is a %s, want an annotated tag", kind)
+    }
+    if message := git(repo, "log", "-1", "--format=%s"); message != "chore(version): bump to v1.3.0" {
+        t.Errorf("release commit message = %q", message)
+    }
+    if tagged, pushed := git(repo, "rev-parse", "v1.3.0^{commit}"), git(repo, "--git-dir", remote, "rev-parse", "v1.3.0^{commit}"); tagged != pushed {
+        t.Errorf("remote v1.3.0 = %s, want %s", pushed, tagged)
+    }
+    if tagged, pushed := git(repo, "rev-parse", "v1.3.0^{commit}"), git(repo, "--git-dir", remote, "rev-parse", branch); tagged != pushed {
+        t.Errorf("remote %s = %s, want %s", branch, pushed, tagged)
+    }
+    if status := git(repo, "status", "--porcelain"); status != "" {
+        t.Errorf("release left changes in the working tree: %s", status)
+    }
+
+    // The working tree must be clean
+    if err := os.WriteFile(repo+"/package.json", []byte("{}\n"), 0644); err != nil {
+        t.Fatalf("Failed to write package.json: %v", err)
+    }
+    if output, err := release(repo, "patch"); err == nil {
+        t.Errorf("Expected error for a release with uncommitted changes, but got %s", output)
+    }
+    git(repo, "checkout", "--", "package.json")
+
+    // The release version must be greatest, --avoid-existing skips a tagged version
+    git(repo, "tag", "v2.0.0", "v1.2.3")
+    if output, err := release(repo, "major"); err == nil {
+        t.Errorf("Expected error for a release that is not greater than v2.0.0, but got %s", output)
+    }
+    if output, err := release(repo, "major", "--avoid-existing", "--message", "release {version}"); err != nil || output != "v3.0.0" {
+        t.Errorf("release major --avoid-existing = %s, %v, want v3.0.0", output, err)
+    }
+    if message := git(repo, "tag", "-l", "--format=%(contents:subject)", "v3.0.0"); message != "release v3.0.0" {
+        t.Errorf("v3.0.0 tag message = %q, want release v3.0.0", message)
+    }
+
+    // Intermediate versions are replaced, an untracked version file is refused and kept
+    repo = newRepo("intermediate", "v1.2.3_feat.1", map[string]string{
+        ".project.yml": "project:\n  name: test\n  modules: [test]\nversion:\n  release:\n    files: [NOTES.md]\n",
+        "NOTES.md":     "Build 1.2.3_feat.1 of 1.2.3, next v1.2.3_feat.10\n",
+    })
+    if err := os.WriteFile(repo+"/VERSION", []byte("mine\n"), 0644); err != nil {
+        t.Fatalf("Failed to write VERSION: %v", err)
+    }
+    if output, err := release(repo, "smart"); err == nil {
+        t.Errorf("Expected error for an untracked VERSION file, but got %s", output)
+    }
+    if readFile(repo, "VERSION") != "mine\n" {
+        t.Errorf("untracked VERSION = %q, want it unchanged", readFile(repo, "VERSION"))
+    }
+    if err := os.Remove(repo + "/VERSION"); err != nil {
+        t.Fatalf("Failed to remove VERSION: %v", err)
+    }
+    if output, err := release(repo, "smart"); err != nil || output != "v1.2.3_feat.2" {
+        t.Fatalf("release smart = %s, %v, want v1.2.3_feat.2", output, err)
+    }
+    if expected := "Build 1.2.3_feat.2 of 1.2.3, next v1.2.3_feat.10\n"; readFile(repo, "NOTES.md") != expected {
+        t.Errorf("NOTES.md = %q, want %q", readFile(repo, "NOTES.md"), expected)
+    }
+    if readFile(repo, "VERSION") != "v1.2.3_feat.2\n" {
+        t.Errorf("VERSION = %q, want v1.2.3_feat.2", readFile(repo, "VERSION"))
+    }
+}
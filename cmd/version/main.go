@@ -1,9 +1,13 @@
 package main
 
 import (
+    "errors"
     "flag"
     "fmt"
     "os"
+    "strings"
+    
+    "github.com/AlexBurnes/version-go/pkg/version"
 )
 
 var appVersion = "1.2.4" // Default version, can be overridden via ldflags
@@ -87,6 +91,16 @@ func printError(format string, args ...interface{}) {
         colors.Red, colors.Bold, colors.Reset, colors.Red, message, colors.Reset)
 }
 
+// printParseErrorPosition prints the invalid version with a caret under the offending character
+func printParseErrorPosition(err error) {
+    var parseErr *version.ParseError
+    if !errors.As(err, &parseErr) {
+        return
+    }
+    fmt.Fprintf(os.Stderr, "    %s\n    %s%s^%s\n",
+        parseErr.Input, strings.Repeat(" ", parseErr.Offset), colors.Red, colors.Reset)
+}
+
 func printWarning(format string, args ...interface{}) {
     message := fmt.Sprintf(format, args...)
     fmt.Fprintf(os.Stderr, "%sWARNING: %s%s\n", colors.Purple, message, colors.Reset)
@@ -132,15 +146,38 @@ Commands:
     project           print project name from git remote
     module            print module name from git remote
     modules           print all module names from .project.yml or single git module name
-    version           print project version from git tags
+    version [--module name]
+                      print project version from git tags (module tags such as api/v1.2.3 with --module)
     release           print project release number
+    release [type] [--dry-run] [--push remote] [--message msg] [--module name] [--pre label] [--avoid-existing]
+                      bump the current version, write VERSION and configured files, commit and create
+                      the annotated tag (rolled back on failure), optionally push them to a remote
     full              print full project name-version-release
-    check [version]   validate version string (uses current git version if not specified)
-    check-greatest [version] check if version is greatest among all tags
-    type [version]    print version type (release, prerelease, postrelease, intermediate)
+    check [version] [--dialect extended|semver2]
+                      validate version string (uses current git version if not specified)
+    satisfies version constraint
+                      check that version satisfies constraint (e.g. ">=1.2.0 <2.0.0", "^1.2", "1.4.x type=postrelease")
+    latest [--constraint expr] [--exclude types]
+                      print highest version tag satisfying constraint, optionally skipping version types
+    check-greatest [version] [--module name]
+                      check if version is greatest among all tags
+    type [version] [--module name]
+                      print version type (release, prerelease, postrelease, intermediate)
     build-type [version] print CMake build type (Release/Debug) based on version type
-    bump [version] [type] bump version with specified type (smart, major, minor, patch, pre, alpha, beta, rc, fix, next, post, feat)
-    sort              sort version strings from stdin
+    bump [version] [type] [--drop-build] [--module name] [--style canonical|git|input] [--pre label] [--branch name] [--avoid-existing]
+                      bump version with specified type (smart, major, minor, patch, pre, alpha, beta, rc, fix, next, post, feat, calendar, revision, release, auto, branch)
+                      or core+label (e.g. minor+alpha)
+    convert [version] --to deb|rpm|pep440|maven|nuget|npm|windows
+                      convert version to the native syntax of a packaging ecosystem
+    compare [--scheme extended|semver2|deb|rpm] version1 version2
+                      compare two versions and print -1, 0 or 1 (deb and rpm follow dpkg and rpmvercmp)
+    diff [--json] version1 version2
+                      describe the change from version1 to version2 (component, direction, bump successor)
+    extract [--all] [--json] [text...]
+                      print the first (or every) version found in text or stdin, e.g. file names and tool output
+    canonical [version]
+                      print the normalized form of version (no 'v' prefix and build metadata, '~' prerelease delimiter)
+    sort              sort version strings from stdin (equal versions such as v1.2.3 and 1.2.3 are printed once)
     platform          print current platform (GOOS value)
     arch              print current architecture (GOARCH value)
     os                print current operating system (user-friendly format)
@@ -149,10 +186,29 @@ Commands:
 
 Examples:
     version check 1.2.3
+    version check --dialect semver2 1.2.3-rc.1+build.5
+    version satisfies 1.4.2.fix.1 "1.4.x type=postrelease"
+    version satisfies 1.2.3 "^1.2 || ~2.0"
     version check-greatest
+    version version --module api
+    version bump --module api minor
+    version bump --style git minor
+    version latest --constraint "1.4.x" --exclude prerelease,intermediate
     version type 1.2.3-alpha.1
     version bump 1.2.3 major
     version bump 1.2.3 alpha
+    version bump 1.2.3 minor+alpha
+    version bump auto
+    version bump branch
+    version release minor --dry-run
+    version release patch --push origin
+    version convert 1.2.3~rc.1 --to deb
+    version convert --to pep440 1.2.3.post.2
+    version compare --scheme deb 1:2.0-1 2.1-3
+    version diff --json 1.2.3 1.4.0
+    version extract myapp-1.4.2-linux-amd64.tar.gz
+    cmake --version | version extract
+    version canonical v1.2.3-rc.01+build.5
     version platform
     version arch
     version os
@@ -182,6 +238,12 @@ func main() {
         os.Exit(1)
     }
 
+    // Apply the version scheme and custom labels before any version is parsed
+    if err := loadVersionConfig(); err != nil {
+        printError("%v", err)
+        os.Exit(1)
+    }
+
     args := flag.Args()
     if len(args) == 0 {
         printError("no command specified")
@@ -205,25 +267,59 @@ func main() {
     case "modules":
         result, err = getModules()
     case "version":
-        result, err = getVersion()
+        if _, e := parseModuleArgs("version", commandArgs); e != nil {
+            err = e
+        } else {
+            result, err = getVersion()
+        }
     case "release":
-        result, err = getRelease()
+        if len(commandArgs) > 0 && (commandArgs[0] == "help" || commandArgs[0] == "--help" || commandArgs[0] == "-h") {
+            printReleaseHelp()
+            os.Exit(0)
+        }
+
+        // Without a type or options release keeps printing the release number
+        if len(commandArgs) == 0 {
+            result, err = getRelease()
+        } else {
+            result, err = releaseVersion(commandArgs)
+        }
     case "full":
         result, err = getFull()
     case "check":
-        if len(commandArgs) > 0 {
-            err = checkVersion(commandArgs[0])
+        checkArgs, dialect, e := parseCheckArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else if len(checkArgs) > 0 {
+            err = checkVersion(checkArgs[0], dialect)
         } else {
             version, e := getVersion()
             if e != nil {
                 err = e
             } else {
-                err = checkVersion(version)
+                err = checkVersion(version, dialect)
             }
         }
+    case "satisfies":
+        if len(commandArgs) < 2 {
+            err = fmt.Errorf("usage: satisfies version constraint")
+        } else {
+            // Constraint terms may be passed as separate arguments
+            err = checkSatisfies(commandArgs[0], strings.Join(commandArgs[1:], " "))
+        }
+    case "latest":
+        constraint, opts, e := parseLatestArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else {
+            result, err = getLatest(constraint, opts)
+        }
     case "check-greatest":
-        if len(commandArgs) > 0 {
-            result, err = checkGreatest(commandArgs[0])
+        greatestArgs, e := parseModuleArgs("check-greatest", commandArgs)
+        if e != nil {
+            err = e
+        } else if len(greatestArgs) > 0 {
+            result, err = checkGreatest(greatestArgs[0])
         } else {
             version, e := getVersion()
             if e != nil {
@@ -233,8 +329,11 @@ func main() {
             }
         }
     case "type":
-        if len(commandArgs) > 0 {
-            result, err = getVersionType(commandArgs[0])
+        typeArgs, e := parseModuleArgs("type", commandArgs)
+        if e != nil {
+            err = e
+        } else if len(typeArgs) > 0 {
+            result, err = getVersionType(typeArgs[0])
         } else {
             version, e := getVersion()
             if e != nil {
@@ -254,33 +353,87 @@ func main() {
                 result, err = getBuildType(version)
             }
         }
+    case "convert":
+        convertArgs, target, e := parseConvertArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else if len(convertArgs) > 0 {
+            result, err = convertVersion(convertArgs[0], target)
+        } else {
+            version, e := getVersion()
+            if e != nil {
+                err = e
+            } else {
+                result, err = convertVersion(version, target)
+            }
+        }
+    case "compare":
+        compareArgs, scheme, e := parseCompareArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else {
+            result, err = compareVersions(compareArgs[0], compareArgs[1], scheme)
+        }
+    case "diff":
+        diffArgs, jsonOutput, e := parseDiffArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else {
+            result, err = diffVersions(diffArgs[0], diffArgs[1], jsonOutput)
+        }
+    case "extract":
+        extractArgs, all, jsonOutput, e := parseExtractArgs(commandArgs)
+        if e != nil {
+            err = e
+        } else {
+            result, err = extractVersions(extractArgs, os.Stdin, all, jsonOutput)
+        }
+    case "canonical":
+        if len(commandArgs) > 0 {
+            result, err = canonicalVersion(commandArgs[0])
+        } else {
+            version, e := getVersion()
+            if e != nil {
+                err = e
+            } else {
+                result, err = canonicalVersion(version)
+            }
+        }
     case "sort":
-        result, err = sortVersions()
+        err = sortVersions(os.Stdin, os.Stdout)
     case "bump":
         if len(commandArgs) > 0 && (commandArgs[0] == "help" || commandArgs[0] == "--help" || commandArgs[0] == "-h") {
             printBumpHelp()
             os.Exit(0)
         }
         
+        // Parse bump options
+        bumpArgs, bumpOptions, e := parseBumpArgs(commandArgs)
+        if e != nil {
+            printError("%v", e)
+            printBumpHelp()
+            os.Exit(1)
+        }
+        
         // Validate arguments
-        if err := validateBumpArgs(commandArgs); err != nil {
-            printError("%v", err)
+        if e := validateBumpArgs(bumpArgs); e != nil {
+            printError("%v", e)
             printBumpHelp()
             os.Exit(1)
         }
         
         // Get version to bump
-        versionToBump, err := getBumpVersion(commandArgs)
-        if err != nil {
-            printError("%v", err)
+        versionToBump, e := getBumpVersion(bumpArgs)
+        if e != nil {
+            printError("%v", e)
             os.Exit(1)
         }
         
         // Get bump type
-        bumpType := getBumpType(commandArgs)
+        bumpType := getBumpType(bumpArgs)
         
         // Perform bump
-        result, err = bumpVersion(versionToBump, bumpType)
+        result, err = bumpVersion(versionToBump, bumpType, bumpOptions)
     case "platform":
         result, err = getPlatform()
     case "arch":
@@ -299,6 +452,7 @@ func main() {
 
     if err != nil {
         printError("%v", err)
+        printParseErrorPosition(err)
         os.Exit(1)
     }
 
@@ -2,11 +2,14 @@ package main
 
 import (
     "fmt"
+    "io"
     "math/rand"
     "os/exec"
     "strings"
     "testing"
     "time"
+    
+    "github.com/AlexBurnes/version-go/pkg/version"
 )
 
 func TestPerformanceWithLargeVersionList(t *testing.T) {
@@ -39,8 +42,8 @@ func TestPerformanceWithLargeVersionList(t *testing.T) {
     
     // Verify output is sorted
     lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-    if len(lines) != len(versions) {
-        t.Errorf("Expected %d sorted versions, got %d", len(versions), len(lines))
+    if len(lines) != uniqueVersionCount(versions) {
+        t.Errorf("Expected %d sorted versions, got %d", uniqueVersionCount(versions), len(lines))
     }
     
     // Check that output is actually sorted by testing a few key comparisons
@@ -96,8 +99,8 @@ func TestPerformanceWithBuiltBinary(t *testing.T) {
     
     // Verify output is sorted
     lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-    if len(lines) != len(versions) {
-        t.Errorf("Expected %d sorted versions, got %d", len(versions), len(lines))
+    if len(lines) != uniqueVersionCount(versions) {
+        t.Errorf("Expected %d sorted versions, got %d", uniqueVersionCount(versions), len(lines))
     }
     
     // Performance assertion - should complete within reasonable time
@@ -108,6 +111,32 @@ func TestPerformanceWithBuiltBinary(t *testing.T) {
     t.Logf("Binary sorted %d versions in %v", len(versions), duration)
 }
 
+func TestSortLongInputLine(t *testing.T) {
+    // A single line longer than the default 64KB scanner buffer
+    versions := generateLargeVersionList(20000)
+    
+    cmd := exec.Command("go", "run", ".", "sort")
+    cmd.Dir = "."
+    cmd.Stdin = strings.NewReader(strings.Join(versions, " "))
+    
+    output, err := cmd.Output()
+    if err != nil {
+        t.Fatalf("Sort command failed: %v", err)
+    }
+    
+    lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+    if len(lines) != uniqueVersionCount(versions) {
+        t.Fatalf("Expected %d sorted versions, got %d", uniqueVersionCount(versions), len(lines))
+    }
+    for i := 1; i < len(lines); i++ {
+        a, _ := version.Parse(lines[i-1])
+        b, _ := version.Parse(lines[i])
+        if version.Compare(a, b) > 0 {
+            t.Fatalf("Output is not sorted at line %d: %s > %s", i, lines[i-1], lines[i])
+        }
+    }
+}
+
 func generateLargeVersionList(count int) []string {
     rand.Seed(time.Now().UnixNano())
     versions := make([]string, count)
@@ -149,6 +178,16 @@ func generateLargeVersionList(count int) []string {
     return versions
 }
 
+// uniqueVersionCount returns the number of distinct versions, sort prints equal
+// versions once (the generated versions are in canonical form)
+func uniqueVersionCount(versions []string) int {
+    seen := make(map[string]bool)
+    for _, v := range versions {
+        seen[v] = true
+    }
+    return len(seen)
+}
+
 func BenchmarkVersionSorting(b *testing.B) {
     versions := generateLargeVersionList(1000)
     
@@ -195,4 +234,74 @@ func BenchmarkVersionValidation(b *testing.B) {
             cmd.Run() // We don't care about the result, just the performance
         }
     }
-}
\ No newline at end of file
+}
+func BenchmarkLibraryParse(b *testing.B) {
+    versions := generateLargeVersionList(1000)
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := version.Parse(versions[i%len(versions)]); err != nil {
+            b.Fatalf("Parse failed: %v", err)
+        }
+    }
+}
+
+func BenchmarkLibraryCompare(b *testing.B) {
+    a, _ := version.Parse("1.2.3~alpha.1_feature.10")
+    c, _ := version.Parse("1.2.3~alpha.1_feature.9")
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        version.Compare(a, c)
+    }
+}
+
+// BenchmarkLibraryCompareUntokenized compares versions that were not parsed,
+// so Compare scans their identifiers instead of using the tokens cached by Parse
+func BenchmarkLibraryCompareUntokenized(b *testing.B) {
+    a := &version.Version{Major: 1, Minor: 2, Patch: 3, Type: version.TypePrerelease, Prerelease: "~alpha.1_feature.10"}
+    c := &version.Version{Major: 1, Minor: 2, Patch: 3, Type: version.TypePrerelease, Prerelease: "~alpha.1_feature.9"}
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        version.Compare(a, c)
+    }
+}
+
+func BenchmarkLibrarySort(b *testing.B) {
+    versions := generateLargeVersionList(50000)
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := version.Sort(versions); err != nil {
+            b.Fatalf("Sort failed: %v", err)
+        }
+    }
+}
+
+// BenchmarkSortStream sorts 50k versions read from a stream, as version sort
+// does with stdin, without building the list of input strings
+func BenchmarkSortStream(b *testing.B) {
+    input := strings.Join(generateLargeVersionList(50000), "\n")
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if err := sortVersions(strings.NewReader(input), io.Discard); err != nil {
+            b.Fatalf("sortVersions failed: %v", err)
+        }
+    }
+}
+
+func BenchmarkLibraryBump(b *testing.B) {
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        if _, err := version.Bump("1.2.3~rc.1_feature.2", version.BumpSmart); err != nil {
+            b.Fatalf("Bump failed: %v", err)
+        }
+    }
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/AlexBurnes/version-go/pkg/version"
+)
+
+// defaultReleaseMessage is the commit and tag message of a release, {version} is the release tag
+const defaultReleaseMessage = "chore(version): bump to {version}"
+
+// releaseFlags holds the options of the release command
+type releaseFlags struct {
+	bump    bumpFlags
+	dryRun  bool
+	push    string // remote the release commit and tag are pushed to
+	message string // commit and tag message, {version} is replaced by the release tag
+}
+
+// parseReleaseArgs parses the release command options and returns the remaining arguments
+func parseReleaseArgs(args []string) ([]string, releaseFlags, error) {
+	var flags releaseFlags
+
+	fs := newCommandFlagSet("release")
+	module := fs.String("module", "", "module from .project.yml that is released")
+	fs.StringVar(&flags.bump.pre, "pre", "", "prerelease label of the new core version (e.g. minor --pre rc)")
+	fs.BoolVar(&flags.bump.opts.AvoidExisting, "avoid-existing", false, "skip bumped versions that are already tagged")
+	fs.BoolVar(&flags.dryRun, "dry-run", false, "print the release plan without changing anything")
+	fs.StringVar(&flags.push, "push", "", "push the release commit and tag to a remote")
+	fs.StringVar(&flags.message, "message", "", "commit and tag message, {version} is replaced by the release tag")
+
+	rest, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return nil, flags, err
+	}
+	if len(rest) > 1 {
+		return nil, flags, fmt.Errorf("too many arguments - usage: release [type]")
+	}
+	if err := selectModule(*module); err != nil {
+		return nil, flags, err
+	}
+	// Release tags never carry build metadata
+	flags.bump.opts.DropBuild = true
+	return rest, flags, nil
+}
+
+// releasePlan is the work of a release: the bumped version, its tag and the files to write
+type releasePlan struct {
+	current string
+	result  *version.BumpResult
+	tag     string
+	tags    int // number of existing version tags
+	message string
+	files   []releaseFile
+	push    string
+	branch  string // branch pushed with the tag, e.g. refs/heads/main
+}
+
+// releaseFile is a file written by a release
+type releaseFile struct {
+	path     string
+	content  []byte
+	mode     fs.FileMode
+	replaced []string // lines whose current version is replaced as path:line: text, nil for the version file
+	created  bool     // the file did not exist before the release
+}
+
+// String returns the plan one step per line, as printed by --dry-run
+func (p *releasePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "release %s -> %s (%s)\n", p.current, p.tag, p.result.AppliedRule)
+	fmt.Fprintf(&b, "check   %s is greater than %d version tags\n", p.tag, p.tags)
+	for _, f := range p.files {
+		if f.replaced == nil {
+			fmt.Fprintf(&b, "write   %s (%s)\n", f.path, strings.TrimSpace(string(f.content)))
+			continue
+		}
+		lines := "lines"
+		if len(f.replaced) == 1 {
+			lines = "line"
+		}
+		fmt.Fprintf(&b, "update  %s (%s on %d %s)\n", f.path, p.current, len(f.replaced), lines)
+		for _, line := range f.replaced {
+			fmt.Fprintf(&b, "        %s\n", line)
+		}
+	}
+	fmt.Fprintf(&b, "commit  %s\n", p.message)
+	fmt.Fprintf(&b, "tag     %s (annotated)", p.tag)
+	if p.push != "" {
+		fmt.Fprintf(&b, "\npush    %s %s %s (atomic)", p.push, strings.TrimPrefix(p.branch, "refs/heads/"), p.tag)
+	}
+	return b.String()
+}
+
+// releaseVersion runs the release workflow: bump the current version, check that
+// it is greatest, write the version file and the configured files, commit them and
+// create an annotated tag, then push to a remote. Any failure rolls back the commit
+// and the tag. With --dry-run the plan is printed instead.
+func releaseVersion(args []string) (string, error) {
+	rest, flags, err := parseReleaseArgs(args)
+	if err != nil {
+		return "", err
+	}
+	bumpTypeStr := "smart"
+	if len(rest) == 1 {
+		bumpTypeStr = rest[0]
+	}
+
+	plan, err := planRelease(bumpTypeStr, flags)
+	if err != nil {
+		return "", err
+	}
+	if flags.dryRun {
+		return plan.String(), nil
+	}
+	if err := executeRelease(plan); err != nil {
+		return "", err
+	}
+	return plan.tag, nil
+}
+
+// planRelease works out the release without changing anything: the working
+// tree must be clean, the files written by the release tracked or missing,
+// the release version greater than all version tags and a pushed release on a branch
+func planRelease(bumpTypeStr string, flags releaseFlags) (*releasePlan, error) {
+	if status, err := runGitCommand("status", "--porcelain", "--untracked-files=no"); err != nil {
+		return nil, err
+	} else if status != "" {
+		return nil, fmt.Errorf("working tree has uncommitted changes, commit or stash them before a release")
+	}
+
+	// A detached HEAD has no branch to push the release commit to
+	var branch string
+	if flags.push != "" {
+		var err error
+		if branch, err = runGitCommand("symbolic-ref", "-q", "HEAD"); err != nil || branch == "" {
+			return nil, fmt.Errorf("HEAD is detached, check out the branch to push the release to")
+		}
+	}
+
+	current, err := getVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %v", err)
+	}
+	result, err := bump(current, bumpTypeStr, flags.bump)
+	if err != nil {
+		return nil, err
+	}
+	plan := &releasePlan{
+		current: current,
+		result:  result,
+		tag:     currentModule.TagPrefix + result.Version.ToGitTag(),
+		push:    flags.push,
+		branch:  branch,
+	}
+
+	// The release version must be greater than every version tag of the module
+	tags, err := getGitTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		v, err := version.Parse(tag)
+		if err != nil {
+			continue
+		}
+		plan.tags++
+		if version.Compare(v, result.Version) >= 0 {
+			return nil, fmt.Errorf("release version %s is not greater than existing tag %s%s", result.BumpedVersion, currentModule.TagPrefix, tag)
+		}
+	}
+
+	config, err := loadProjectConfig()
+	if err != nil {
+		printDebug("No release configuration loaded, failed to load project configuration: %v", err)
+	}
+	var release version.ReleaseConfig
+	if config != nil {
+		release = config.Version.Release
+	}
+	if release.VersionFile == "" {
+		release.VersionFile = "VERSION"
+	}
+	if release.Message == "" {
+		release.Message = defaultReleaseMessage
+	}
+	if flags.message != "" {
+		release.Message = flags.message
+	}
+	plan.message = strings.ReplaceAll(release.Message, "{version}", plan.tag)
+
+	versionFile, err := readReleaseFile(release.VersionFile)
+	if err != nil {
+		return nil, err
+	}
+	versionFile.content = []byte(result.Version.ToGitTag() + "\n")
+	plan.files = append(plan.files, versionFile)
+
+	currentVersion, err := version.Parse(current)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range release.Files {
+		f, err := readReleaseFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if f.created {
+			return nil, fmt.Errorf("release file %s does not exist", file.Path)
+		}
+		if f.content, f.replaced = replaceVersion(f.content, file, currentVersion, result.Version); len(f.replaced) == 0 {
+			if file.Pattern != "" {
+				return nil, fmt.Errorf("release file %s does not contain the current version %s in pattern '%s'", file.Path, current, file.Pattern)
+			}
+			return nil, fmt.Errorf("release file %s does not contain the current version %s", file.Path, current)
+		}
+		plan.files = append(plan.files, f)
+	}
+	return plan, nil
+}
+
+// readReleaseFile reads a file written by a release, a missing file is created by it.
+// An existing file must be tracked by git, so that a rollback restores it.
+func readReleaseFile(path string) (releaseFile, error) {
+	f := releaseFile{path: path, mode: 0644}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		f.created = true
+		return f, nil
+	}
+	if err != nil {
+		return f, fmt.Errorf("failed to read release file %s: %v", path, err)
+	}
+	if _, err := runGitCommand("ls-files", "--error-unmatch", "--", path); err != nil {
+		return f, fmt.Errorf("release file %s is not tracked by git, commit or remove it before a release", path)
+	}
+	f.mode = info.Mode().Perm()
+	if f.content, err = os.ReadFile(path); err != nil {
+		return f, fmt.Errorf("failed to read release file %s: %v", path, err)
+	}
+	return f, nil
+}
+
+// versionSpan is a version equal to the current version in a release file
+type versionSpan struct {
+	offset int
+	length int
+	style  version.Style
+}
+
+// replaceVersion replaces the versions equal to current in content by next,
+// written in the style of the replaced text (v1.2.3-rc.1 -> v1.3.0). With a
+// pattern only the version at its {version} is replaced. It returns the new
+// content and the replaced lines as path:line: text.
+func replaceVersion(content []byte, file version.ReleaseFile, current, next *version.Version) ([]byte, []string) {
+	text := string(content)
+	spans := findVersionSpans(text, current)
+	if file.Pattern != "" {
+		spans = findPatternSpans(text, file.Pattern, current)
+	}
+
+	var b strings.Builder
+	var lines []string
+	end, lastLine := 0, 0
+	for _, span := range spans {
+		b.WriteString(text[end:span.offset])
+		b.WriteString(next.Format(span.style))
+		end = span.offset + span.length
+
+		if line := 1 + strings.Count(text[:span.offset], "\n"); line != lastLine {
+			start := strings.LastIndexByte(text[:span.offset], '\n') + 1
+			stop := len(text)
+			if i := strings.IndexByte(text[span.offset:], '\n'); i >= 0 {
+				stop = span.offset + i
+			}
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", file.Path, line, strings.TrimSpace(text[start:stop])))
+			lastLine = line
+		}
+	}
+	b.WriteString(text[end:])
+	return []byte(b.String()), lines
+}
+
+// findVersionSpans returns the versions equal to current found by version.FindAll.
+// FindAll ends versions before '_', so the intermediate identifier of an
+// intermediate current version (1.2.3_feat.1) is matched after the found version.
+func findVersionSpans(text string, current *version.Version) []versionSpan {
+	var spans []versionSpan
+	for _, m := range version.FindAll(text) {
+		v, length := m.Version, len(m.Text)
+		if current.Type == version.TypeIntermediate {
+			end := m.Offset + length
+			if !strings.HasPrefix(text[end:], current.Intermediate) || !versionEnd(text, end+len(current.Intermediate)) {
+				continue
+			}
+			length += len(current.Intermediate)
+			var err error
+			if v, err = version.Parse(text[m.Offset : m.Offset+length]); err != nil {
+				continue
+			}
+		}
+		if version.Equal(v, current) {
+			spans = append(spans, versionSpan{offset: m.Offset, length: length, style: v.Style})
+		}
+	}
+	return spans
+}
+
+// findPatternSpans returns the versions equal to current at {version} of a
+// pattern: the text after the pattern's text before {version} up to its text
+// after {version} on the same line, or up to the end of the version without it
+func findPatternSpans(text, pattern string, current *version.Version) []versionSpan {
+	before, after, _ := strings.Cut(pattern, "{version}")
+	var spans []versionSpan
+	for i := 0; ; {
+		j := strings.Index(text[i:], before)
+		if j < 0 {
+			return spans
+		}
+		start := i + j + len(before)
+		i = start
+
+		end := start
+		if after != "" {
+			line := text[start:]
+			if k := strings.IndexByte(line, '\n'); k >= 0 {
+				line = line[:k]
+			}
+			k := strings.Index(line, after)
+			if k < 0 {
+				continue
+			}
+			end = start + k
+		} else {
+			for end < len(text) && isVersionByte(text[end]) {
+				end++
+			}
+		}
+
+		if v, err := version.Parse(text[start:end]); err == nil && version.Equal(v, current) {
+			spans = append(spans, versionSpan{offset: start, length: end - start, style: v.Style})
+			i = end
+		}
+	}
+}
+
+// versionEnd reports whether a version may end before offset i of text,
+// i.e. it is not followed by a letter, a digit or '.' and a digit
+func versionEnd(text string, i int) bool {
+	switch {
+	case i == len(text):
+		return true
+	case isAlnumByte(text[i]):
+		return false
+	case text[i] == '.' && i+1 < len(text) && text[i+1] >= '0' && text[i+1] <= '9':
+		return false
+	}
+	return true
+}
+
+// isVersionByte reports whether c may be part of a version
+func isVersionByte(c byte) bool {
+	return isAlnumByte(c) || strings.IndexByte(".-~_+", c) >= 0
+}
+
+// isAlnumByte reports whether c is an ASCII letter or digit
+func isAlnumByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// executeRelease writes the files, commits them, creates the annotated tag and
+// pushes both in one atomic push. Any failure rolls back the commit, the tag
+// and the written files.
+func executeRelease(plan *releasePlan) (err error) {
+	head, err := runGitCommand("rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	tagged := false
+	defer func() {
+		if err != nil {
+			rollbackRelease(plan, head, tagged)
+			err = fmt.Errorf("%v, release %s rolled back", err, plan.tag)
+		}
+	}()
+
+	paths := []string{"add", "--"}
+	for _, f := range plan.files {
+		if err := os.WriteFile(f.path, f.content, f.mode); err != nil {
+			return fmt.Errorf("failed to write %s: %v", f.path, err)
+		}
+		printDebug("Wrote %s", f.path)
+		paths = append(paths, f.path)
+	}
+	if _, err := runGitCommand(paths...); err != nil {
+		return fmt.Errorf("failed to stage release files: %v", err)
+	}
+	if _, err := runGitCommand("commit", "-q", "-m", plan.message); err != nil {
+		return fmt.Errorf("failed to commit release: %v", err)
+	}
+	printDebug("Committed %s", plan.message)
+	if _, err := runGitCommand("tag", "-a", plan.tag, "-m", plan.message); err != nil {
+		return fmt.Errorf("failed to create tag %s: %v", plan.tag, err)
+	}
+	tagged = true
+	printDebug("Created tag %s", plan.tag)
+
+	if plan.push != "" {
+		if _, err := runGitCommand("push", "-q", "--atomic", plan.push, plan.branch, "refs/tags/"+plan.tag); err != nil {
+			return fmt.Errorf("failed to push release to %s: %v", plan.push, err)
+		}
+		printDebug("Pushed %s and %s to %s", plan.branch, plan.tag, plan.push)
+	}
+	return nil
+}
+
+// rollbackRelease restores the state before a release: the tag is deleted, HEAD
+// and the tracked release files are reset to the previous commit and created
+// files removed
+func rollbackRelease(plan *releasePlan, head string, tagged bool) {
+	if tagged {
+		if _, err := runGitCommand("tag", "-d", plan.tag); err != nil {
+			printError("rollback: failed to delete tag %s: %v", plan.tag, err)
+		}
+	}
+	if _, err := runGitCommand("reset", "-q", "--hard", head); err != nil {
+		printError("rollback: failed to reset to %s: %v", head, err)
+	}
+	for _, f := range plan.files {
+		if f.created {
+			if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				printError("rollback: failed to remove %s: %v", f.path, err)
+			}
+		}
+	}
+	printDebug("Rolled back release %s to %s", plan.tag, head)
+}
+
+// printReleaseHelp prints help for the release command
+func printReleaseHelp() {
+	fmt.Printf(`Release command usage:
+    version release [type] [options]
+
+Works out the next version of the current git version (bump type, default smart),
+checks that it is greater than all version tags, then in a single transaction:
+    1. writes the version file (VERSION) with the release tag, e.g. v1.3.0
+    2. replaces the current version in the files of version.release.files in .project.yml
+    3. commits the files and creates the annotated tag
+    4. pushes the current branch and the tag atomically (--push remote)
+A failure in any step rolls back the commit, the tag and the written files.
+The working tree must not have uncommitted changes and existing release files
+must be tracked by git. Without a type or options release prints the release number.
+
+Options:
+    --dry-run        Print the release plan, including every replaced line, without changing anything
+    --push remote    Push the current branch and the release tag to a remote
+                     (HEAD must not be detached)
+    --message msg    Commit and tag message, {version} is the release tag
+                     (default "chore(version): bump to {version}")
+    --module name    Release a module from .project.yml (tags such as api/v1.3.0)
+    --pre label      Start a prerelease of the new core version (minor --pre rc)
+    --avoid-existing Skip release versions that are already tagged
+
+Configuration (.project.yml):
+    version:
+      release:
+        version_file: VERSION            # written with the release tag
+        files:
+          - CMakeLists.txt               # every occurrence of the current version is replaced
+          - path: package.json           # only the version at {version} is replaced
+            pattern: '"version": "{version}"'
+        message: "release {version}"
+
+Examples:
+    version release minor --dry-run  # Show the plan of the next minor release
+    version release patch --push origin
+    version release minor --pre rc   # Tag the first release candidate of the next minor version
+    version release --module api release  # Finalize the api prerelease
+`)
+}
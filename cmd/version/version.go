@@ -2,16 +2,201 @@ package main
 
 import (
     "bufio"
+    "encoding/json"
     "fmt"
-    "os"
+    "io"
     "strings"
     
     "github.com/AlexBurnes/version-go/pkg/version"
 )
 
 // checkVersion validates a version string using the library
-func checkVersion(versionStr string) error {
-    return version.Validate(versionStr)
+func checkVersion(versionStr string, dialect version.Dialect) error {
+    _, err := version.ParseWithOptions(versionStr, dialect)
+    return err
+}
+
+// parseCheckArgs parses the check command options and returns the remaining arguments
+func parseCheckArgs(args []string) ([]string, version.Dialect, error) {
+    fs := newCommandFlagSet("check")
+    dialectStr := fs.String("dialect", "extended", "version grammar dialect (extended, semver2)")
+    
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return nil, version.DialectExtended, err
+    }
+    
+    dialect, err := version.ParseDialect(*dialectStr)
+    if err != nil {
+        return nil, version.DialectExtended, err
+    }
+    printDebug("Using %s dialect", dialect)
+    return rest, dialect, nil
+}
+
+// checkSatisfies validates that a version satisfies a constraint expression
+func checkSatisfies(versionStr string, constraintStr string) error {
+    ok, err := version.Satisfies(versionStr, constraintStr)
+    if err != nil {
+        return err
+    }
+    if !ok {
+        return fmt.Errorf("version %s does not satisfy constraint '%s'", versionStr, constraintStr)
+    }
+    printDebug("Version %s satisfies constraint '%s'", versionStr, constraintStr)
+    return nil
+}
+
+// parseConvertArgs parses the convert command options and returns the remaining arguments
+func parseConvertArgs(args []string) ([]string, version.Target, error) {
+    fs := newCommandFlagSet("convert")
+    targetStr := fs.String("to", "", "target format (deb, rpm, pep440, maven, nuget, npm, windows)")
+    
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return nil, version.TargetDeb, err
+    }
+    if *targetStr == "" {
+        return nil, version.TargetDeb, fmt.Errorf("missing target format - usage: convert [version] --to deb|rpm|pep440|maven|nuget|npm|windows")
+    }
+    
+    target, err := version.ParseTarget(*targetStr)
+    if err != nil {
+        return nil, version.TargetDeb, err
+    }
+    return rest, target, nil
+}
+
+// convertVersion converts a version string to the syntax of a packaging ecosystem
+func convertVersion(versionStr string, target version.Target) (string, error) {
+    v, err := version.Parse(versionStr)
+    if err != nil {
+        return "", err
+    }
+    return version.Convert(v, target)
+}
+
+// parseCompareArgs parses the compare command options and returns the remaining arguments
+func parseCompareArgs(args []string) ([]string, string, error) {
+    fs := newCommandFlagSet("compare")
+    scheme := fs.String("scheme", "extended", "comparison scheme (extended, semver2, deb, rpm)")
+    
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return nil, "", err
+    }
+    if len(rest) != 2 {
+        return nil, "", fmt.Errorf("usage: compare [--scheme extended|semver2|deb|rpm] version1 version2")
+    }
+    return rest, strings.ToLower(*scheme), nil
+}
+
+// compareVersions compares two version strings using the given scheme and returns -1, 0 or 1
+func compareVersions(a, b, scheme string) (string, error) {
+    var result int
+    switch scheme {
+    case "deb", "debian":
+        r, err := version.CompareDeb(a, b)
+        if err != nil {
+            return "", err
+        }
+        result = r
+    case "rpm":
+        r, err := version.CompareRPM(a, b)
+        if err != nil {
+            return "", err
+        }
+        result = r
+    default:
+        dialect, err := version.ParseDialect(scheme)
+        if err != nil {
+            return "", fmt.Errorf("unknown scheme: %s (supported: extended, semver2, deb, rpm)", scheme)
+        }
+        va, err := version.ParseWithOptions(a, dialect)
+        if err != nil {
+            return "", err
+        }
+        vb, err := version.ParseWithOptions(b, dialect)
+        if err != nil {
+            return "", err
+        }
+        result = version.Compare(va, vb)
+    }
+    
+    printDebug("Compare %s %s using %s scheme: %d", a, b, scheme, result)
+    switch {
+    case result < 0:
+        return "-1", nil
+    case result > 0:
+        return "1", nil
+    default:
+        return "0", nil
+    }
+}
+
+// parseDiffArgs parses the diff command options and returns the remaining arguments
+func parseDiffArgs(args []string) ([]string, bool, error) {
+    fs := newCommandFlagSet("diff")
+    jsonOutput := fs.Bool("json", false, "print the diff as JSON")
+    
+    rest, err := parseCommandFlags(fs, args)
+    if err != nil {
+        return nil, false, err
+    }
+    if len(rest) != 2 {
+        return nil, false, fmt.Errorf("usage: diff [--json] version1 version2")
+    }
+    return rest, *jsonOutput, nil
+}
+
+// diffOutput is the JSON form of a version diff
+type diffOutput struct {
+    From      string `json:"from"`
+    To        string `json:"to"`
+    Change    string `json:"change"`
+    Direction string `json:"direction"`
+    Delta     int    `json:"delta"`
+    FromType  string `json:"from_type"`
+    ToType    string `json:"to_type"`
+    Successor bool   `json:"successor"`
+    Bump      string `json:"bump,omitempty"`
+}
+
+// diffVersions describes the change from version a to version b
+func diffVersions(a, b string, jsonOutput bool) (string, error) {
+    va, err := version.Parse(a)
+    if err != nil {
+        return "", err
+    }
+    vb, err := version.Parse(b)
+    if err != nil {
+        return "", err
+    }
+    
+    diff := version.Diff(va, vb)
+    printDebug("Diff %s %s: %s", a, b, diff)
+    if !jsonOutput {
+        return diff.String(), nil
+    }
+    
+    output := diffOutput{
+        From:      diff.From,
+        To:        diff.To,
+        Change:    diff.Change.String(),
+        Direction: diff.Direction.String(),
+        Delta:     diff.Delta,
+        FromType:  diff.FromType.String(),
+        ToType:    diff.ToType.String(),
+        Successor: diff.Successor,
+    }
+    if diff.Successor {
+        output.Bump = diff.BumpType.String()
+    }
+    data, err := json.Marshal(output)
+    if err != nil {
+        return "", err
+    }
+    return string(data), nil
 }
 
 // getVersionType returns the type of a version string using the library
@@ -28,33 +213,113 @@ func getBuildType(versionStr string) (string, error) {
     return version.GetBuildType(versionStr)
 }
 
-// sortVersions sorts version strings from stdin using the library
-func sortVersions() (string, error) {
-    scanner := bufio.NewScanner(os.Stdin)
-    var versions []string
+// sortVersions sorts whitespace separated version strings read from in and
+// writes them to out one per line. Input is read word by word, so long lines
+// and large inputs are not limited by the line buffer size. Each version is
+// parsed as it is read and sorted as parsed, the input strings are not kept
+// and an invalid version stops reading.
+func sortVersions(in io.Reader, out io.Writer) error {
+    scanner := bufio.NewScanner(in)
+    scanner.Split(bufio.ScanWords)
+    var versions []*version.Version
     
     for scanner.Scan() {
-        line := strings.TrimSpace(scanner.Text())
-        if line != "" {
-            // Split line by whitespace to handle multiple versions per line
-            parts := strings.Fields(line)
-            versions = append(versions, parts...)
+        v, err := version.Parse(scanner.Text())
+        if err != nil {
+            return fmt.Errorf("invalid version '%s': %w", scanner.Text(), err)
         }
+        versions = append(versions, v)
     }
     
     if err := scanner.Err(); err != nil {
-        return "", fmt.Errorf("error reading input: %v", err)
+        return fmt.Errorf("error reading input: %v", err)
     }
     
-    if len(versions) == 0 {
-        return "", nil
+    w := bufio.NewWriter(out)
+    for _, v := range version.SortVersions(versions) {
+        w.WriteString(v.Original)
+        w.WriteByte('\n')
     }
+    return w.Flush()
+}
+
+// parseExtractArgs parses the extract command options and returns the text arguments
+func parseExtractArgs(args []string) ([]string, bool, bool, error) {
+    fs := newCommandFlagSet("extract")
+    all := fs.Bool("all", false, "print every version found, one per line")
+    jsonOutput := fs.Bool("json", false, "print the versions found as JSON with their offsets")
     
-    // Sort versions using the library
-    sortedVersions, err := version.Sort(versions)
+    rest, err := parseCommandFlags(fs, args)
     if err != nil {
-        return "", err
+        return nil, false, false, err
+    }
+    return rest, *all, *jsonOutput, nil
+}
+
+// extractOutput is the JSON form of a version found in text
+type extractOutput struct {
+    Text    string `json:"text"`
+    Version string `json:"version"`
+    Type    string `json:"type"`
+    Offset  int    `json:"offset"`
+}
+
+// extractVersions finds versions in the text arguments joined by spaces, or in
+// the input when there are none, and prints them as written in the text
+func extractVersions(args []string, in io.Reader, all, jsonOutput bool) (string, error) {
+    text := strings.Join(args, " ")
+    if len(args) == 0 {
+        data, err := io.ReadAll(in)
+        if err != nil {
+            return "", fmt.Errorf("error reading input: %v", err)
+        }
+        text = string(data)
+    }
+    
+    matches := version.FindAll(text)
+    if len(matches) == 0 {
+        return "", fmt.Errorf("no version found")
+    }
+    if !all {
+        matches = matches[:1]
+    }
+    printDebug("Extracted %d versions", len(matches))
+    
+    if !jsonOutput {
+        lines := make([]string, len(matches))
+        for i, match := range matches {
+            lines[i] = match.Text
+        }
+        return strings.Join(lines, "\n"), nil
     }
     
-    return strings.Join(sortedVersions, "\n"), nil
+    outputs := make([]extractOutput, len(matches))
+    for i, match := range matches {
+        outputs[i] = extractOutput{
+            Text:    match.Text,
+            Version: match.Version.String(),
+            Type:    match.Version.Type.String(),
+            Offset:  match.Offset,
+        }
+    }
+    var data []byte
+    var err error
+    if all {
+        data, err = json.Marshal(outputs)
+    } else {
+        data, err = json.Marshal(outputs[0])
+    }
+    if err != nil {
+        return "", err
+    }
+    return string(data), nil
+}
+
+// canonicalVersion returns the canonical form of a version string
+func canonicalVersion(versionStr string) (string, error) {
+    v, err := version.Parse(versionStr)
+    if err != nil {
+        return "", err
+    }
+    return v.Canonical(), nil
 }
\ No newline at end of file
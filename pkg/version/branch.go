@@ -0,0 +1,106 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BranchIdentifier sanitizes a git branch name into an intermediate identifier:
+// the last path component is lowercased, its runs of letters and digits are
+// kept and joined with '_' (feature/PAY-123-refund -> pay_123_refund). An
+// identifier that would start with a number starts with "branch" (fix/42 ->
+// branch_42). The path prefix is dropped on purpose so that versions stay
+// short, branches that differ only in it (feature/x, bugfix/x) share the
+// identifier and continue each other's numbers.
+func BranchIdentifier(branch string) (string, error) {
+	name := strings.ToLower(branch[strings.LastIndex(branch, "/")+1:])
+
+	var b strings.Builder
+	for i := 0; i < len(name); {
+		start := i
+		switch {
+		case isAlpha(name[i]):
+			for i < len(name) && isAlpha(name[i]) {
+				i++
+			}
+		case isDigit(name[i]):
+			for i < len(name) && isDigit(name[i]) {
+				i++
+			}
+			if b.Len() == 0 {
+				b.WriteString("branch")
+			}
+		default:
+			i++
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		if isDigit(name[start]) {
+			b.WriteString(trimLeadingZeros(name[start:i]))
+		} else {
+			b.WriteString(name[start:i])
+		}
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("branch name '%s' has no letters or digits for an intermediate identifier", branch)
+	}
+	return b.String(), nil
+}
+
+// bumpBranch converts a version to the intermediate version of the branch in
+// opts.Branch, identifier.N of the same core version. N continues after the
+// greatest number of the identifier in the version and opts.Tags, or is the
+// identifier start of the bump policy when the branch has no versions yet.
+func bumpBranch(version *Version, opts BumpOptions) (*Version, string, error) {
+	if opts.Branch == "" {
+		return nil, "", fmt.Errorf("branch bump requires a branch name (BumpOptions.Branch)")
+	}
+	identifier, err := BranchIdentifier(opts.Branch)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := "_" + identifier + "."
+	next, after := opts.Scheme.policy().Start, ""
+	if n, ok := branchNumber(version.Intermediate, prefix); ok {
+		next, after = n+1, version.Original
+	}
+	for _, tag := range opts.Tags {
+		v, err := opts.Scheme.Parse(tag)
+		if err != nil || v.Major != version.Major || v.Minor != version.Minor ||
+			v.Patch != version.Patch || v.Extra != version.Extra {
+			continue
+		}
+		if n, ok := branchNumber(v.Intermediate, prefix); ok && n >= next {
+			next, after = n+1, tag
+		}
+	}
+
+	intermediate := prefix + strconv.Itoa(next)
+	bumped := &Version{
+		Major:        version.Major,
+		Minor:        version.Minor,
+		Patch:        version.Patch,
+		Type:         TypeIntermediate,
+		Intermediate: intermediate,
+		Original:     fmt.Sprintf("%d.%d.%d%s", version.Major, version.Minor, version.Patch, intermediate),
+	}
+	if after == "" {
+		return bumped, "convert to intermediate of branch " + opts.Branch + " with " + intermediate[1:], nil
+	}
+	return bumped, "increment intermediate of branch " + opts.Branch + " after " + after, nil
+}
+
+// branchNumber returns the number of an intermediate identifier prefix N,
+// e.g. 2 for _pay_123_refund.2 with the prefix _pay_123_refund.
+func branchNumber(intermediate, prefix string) (int, bool) {
+	digits, ok := strings.CutPrefix(intermediate, prefix)
+	if !ok || !isNumericIdentifier(digits) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
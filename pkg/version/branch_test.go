@@ -0,0 +1,91 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBranchIdentifier(t *testing.T) {
+	tests := []struct {
+		branch   string
+		expected string
+		wantErr  bool
+	}{
+		{"feature/PAY-123-refund", "pay_123_refund", false},
+		{"main", "main", false},
+		{"users/alex/fix_login-timeout", "fix_login_timeout", false},
+		{"release-2.0", "release_2_0", false},
+		{"fix/42", "branch_42", false},
+		{"hotfix/007-crash", "branch_7_crash", false},
+		{"PAY123refund", "pay_123_refund", false},
+		{"feature/", "", true},
+		{"--", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.branch, func(t *testing.T) {
+			identifier, err := BranchIdentifier(test.branch)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("BranchIdentifier(%s) error = %v, wantErr %v", test.branch, err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if identifier != test.expected {
+				t.Errorf("BranchIdentifier(%s) = %s, want %s", test.branch, identifier, test.expected)
+			}
+			if !IsValid("1.2.3_" + identifier + ".1") {
+				t.Errorf("1.2.3_%s.1 is not a valid version", identifier)
+			}
+		})
+	}
+}
+
+func TestBumpBranch(t *testing.T) {
+	tags := []string{"v1.2.3", "v1.2.3_pay_123_refund.1", "v1.2.3_pay_123_refund.2", "v1.2.3_pay_123_refund.10_old", "v1.2.2_pay_123_refund.7", "v1.2.3_other.5"}
+
+	tests := []struct {
+		name     string
+		version  string
+		branch   string
+		tags     []string
+		expected string
+		rule     string // part of the applied rule
+	}{
+		{"new branch", "1.2.3", "feature/PAY-123-refund", nil, "1.2.3_pay_123_refund.1", "convert to intermediate of branch feature/PAY-123-refund with pay_123_refund.1"},
+		{"existing tags", "1.2.3", "feature/PAY-123-refund", tags, "1.2.3_pay_123_refund.3", "after v1.2.3_pay_123_refund.2"},
+		{"branch version", "1.2.3_pay_123_refund.4", "feature/PAY-123-refund", tags, "1.2.3_pay_123_refund.5", "after 1.2.3_pay_123_refund.4"},
+		{"other branch version", "1.2.3_other.5", "feature/PAY-123-refund", tags, "1.2.3_pay_123_refund.3", ""},
+		{"other core", "1.2.4", "feature/PAY-123-refund", tags, "1.2.4_pay_123_refund.1", ""},
+		{"prerelease", "1.3.0~rc.1", "fix/login", nil, "1.3.0_login.1", ""},
+		{"same last path component", "1.2.3", "bugfix/PAY-123-refund", tags, "1.2.3_pay_123_refund.3", "after v1.2.3_pay_123_refund.2"},
+		{"build metadata", "1.2.3+build.5", "main", nil, "1.2.3_main.1+build.5", "carry build metadata +build.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := BumpWithOptions(test.version, BumpBranch, BumpOptions{Branch: test.branch, Tags: test.tags})
+			if err != nil {
+				t.Fatalf("BumpWithOptions(%s, branch) failed: %v", test.version, err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("BumpWithOptions(%s, branch %s) = %s, want %s", test.version, test.branch, result.BumpedVersion, test.expected)
+			}
+			if !strings.Contains(result.AppliedRule, test.rule) {
+				t.Errorf("AppliedRule = %q, want it to contain %q", result.AppliedRule, test.rule)
+			}
+		})
+	}
+
+	if _, err := Bump("1.2.3", BumpBranch); err == nil {
+		t.Errorf("Expected error for a branch bump without a branch")
+	}
+	if spec, err := ParseBumpSpec("branch"); err != nil || spec != (BumpSpec{Core: BumpBranch, Label: BumpNone}) {
+		t.Errorf("ParseBumpSpec(branch) = %+v, %v", spec, err)
+	}
+
+	useSegments(t, 4)
+	if result, err := BumpWithOptions("1.2.3.4", BumpBranch, BumpOptions{Branch: "main"}); err != nil || result.BumpedVersion != "1.2.3.4_main.1" {
+		t.Errorf("BumpWithOptions(1.2.3.4, branch) = %v, %v, want 1.2.3.4_main.1", result, err)
+	}
+}
@@ -2,9 +2,9 @@ package version
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // BumpType represents the type of version bump to perform
@@ -22,7 +22,14 @@ const (
 	BumpNext
 	BumpPost
 	BumpFeat
-	BumpSmart // Smart increment based on current version state
+	BumpSmart      // Smart increment based on current version state
+	BumpCalendar   // Advance a calendar version to the current date
+	BumpRevision   // Increment the fourth numeric segment of an N-part version
+	BumpNone       // No bump, an empty part of a BumpSpec
+	BumpRelease    // Finalize a prerelease to the release of its core version
+	BumpAuto       // Major, minor or patch chosen from Conventional Commits, see BumpFromCommits
+	BumpPrerelease // Bump to the default prerelease label of the bump policy
+	BumpBranch     // Intermediate version of the branch in BumpOptions.Branch, see BranchIdentifier
 )
 
 func (bt BumpType) String() string {
@@ -51,7 +58,24 @@ func (bt BumpType) String() string {
 		return "feat"
 	case BumpSmart:
 		return "smart"
+	case BumpCalendar:
+		return "calendar"
+	case BumpRevision:
+		return "revision"
+	case BumpNone:
+		return "none"
+	case BumpRelease:
+		return "release"
+	case BumpAuto:
+		return "auto"
+	case BumpPrerelease:
+		return "prerelease"
+	case BumpBranch:
+		return "branch"
 	default:
+		if label, ok := CurrentLabelRegistry().bumpLabel(bt); ok {
+			return label.Name
+		}
 		return "unknown"
 	}
 }
@@ -62,21 +86,140 @@ type BumpResult struct {
 	BumpedVersion   string
 	BumpType        BumpType
 	AppliedRule     string
+	Version         *Version // Bumped version with the Style of the original version
+	Skipped         []string // Bumped versions skipped because they are already tagged, with AvoidExisting
 }
 
-// Bump bumps a version according to the specified bump type
+// BumpOptions controls optional behavior of a version bump
+type BumpOptions struct {
+	DropBuild bool      // Drop build metadata instead of carrying it to the bumped version
+	Date      time.Time // Date of calendar bumps, CalVerDate() when zero
+	Branch    string    // Branch name of branch bumps, GetBranch() for the current branch
+	Tags      []string  // Existing version tags, branch bumps continue after the tags of their branch
+	// AvoidExisting skips bumped versions that are already tagged: the bump is
+	// repeated until the version is not in Tags, or in GetTags() when Tags is nil
+	AvoidExisting bool
+	// Scheme parses the version and the tags and provides the labels, the
+	// calendar versioning format, the segments and the bump policy of the bump,
+	// the package defaults when nil. The bumped version keeps the scheme.
+	Scheme *Scheme
+}
+
+// Bump bumps a version according to the specified bump type.
+// Build metadata of the original version is carried through to the bumped version.
+// BumpedVersion is written in canonical style, Version.Format(Version.Style) writes the
+// bumped version in the style of the original (v1.2.3-rc.1 -> v1.2.3-rc.2).
 func Bump(versionStr string, bumpType BumpType) (*BumpResult, error) {
-	version, err := Parse(versionStr)
+	return BumpWithOptions(versionStr, bumpType, BumpOptions{})
+}
+
+// BumpWithOptions bumps a version according to the specified bump type and options.
+// With a calendar versioning scheme (SetCalVer, Scheme.CalVer) a smart bump of a release advances
+// the calendar date, and major, minor and patch bumps are rejected. The bump
+// policy (SetBumpPolicy, Scheme.Policy) decides smart and prerelease bumps, whether postreleases
+// are allowed and the number of new identifiers.
+func BumpWithOptions(versionStr string, bumpType BumpType, opts BumpOptions) (*BumpResult, error) {
+	return bumpSteps(versionStr, opts, bumpType)
+}
+
+// bumpSteps bumps a version by each bump type in turn. Build metadata and the
+// style of the original version are applied to the final version.
+func bumpSteps(versionStr string, opts BumpOptions, bumpTypes ...BumpType) (*BumpResult, error) {
+	version, err := opts.Scheme.Parse(versionStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid version '%s': %v", versionStr, err)
 	}
 
+	bumpedVersion := version
+	rules := make([]string, 0, len(bumpTypes))
+	for _, bumpType := range bumpTypes {
+		var rule string
+		if bumpedVersion, rule, err = bumpStep(bumpedVersion, bumpType, opts); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	appliedRule := strings.Join(rules, ", then ")
+
+	var skipped []string
+	if opts.AvoidExisting {
+		if bumpedVersion, skipped, err = avoidExisting(bumpedVersion, bumpTypes[len(bumpTypes)-1], opts); err != nil {
+			return nil, err
+		}
+		if len(skipped) > 0 {
+			appliedRule += ", skip existing " + strings.Join(skipped, ", ")
+		}
+	}
+	if bumpedVersion.Type == TypePostrelease && !opts.Scheme.policy().Postrelease {
+		return nil, fmt.Errorf("bump to postrelease %s is disallowed by the bump policy", bumpedVersion)
+	}
+
+	if version.Build != "" {
+		if opts.DropBuild {
+			appliedRule += ", drop build metadata " + version.Build
+		} else {
+			bumpedVersion.Build = version.Build
+			bumpedVersion.Original += version.Build
+			appliedRule += ", carry build metadata " + version.Build
+		}
+	}
+
+	bumpedVersion.Style = version.Style
+
+	return &BumpResult{
+		OriginalVersion: version.Original,
+		BumpedVersion:   bumpedVersion.String(),
+		BumpType:        bumpTypes[len(bumpTypes)-1],
+		AppliedRule:     appliedRule,
+		Version:         bumpedVersion,
+		Skipped:         skipped,
+	}, nil
+}
+
+// bumpStep bumps a version without build metadata by one bump type and returns
+// the bumped version and the applied rule
+func bumpStep(version *Version, bumpType BumpType, opts BumpOptions) (*Version, string, error) {
+	calver := opts.Scheme.calver()
+	if calver != nil && (bumpType == BumpMajor || bumpType == BumpMinor || bumpType == BumpPatch) {
+		return nil, "", fmt.Errorf("%s bump is not supported by calendar versioning scheme %s, use calendar", bumpType, calver)
+	}
+
 	var bumpedVersion *Version
 	var appliedRule string
+	var err error
 
 	switch bumpType {
 	case BumpSmart:
-		bumpedVersion, appliedRule = bumpSmart(version)
+		if spec, ok := opts.Scheme.policy().smartBump(version.Type); ok {
+			return bumpPolicy(version, spec, opts)
+		}
+		switch {
+		case calver != nil && version.Type == TypeRelease:
+			bumpedVersion, appliedRule, err = bumpCalendar(version, calver, opts.Date)
+		case version.Extra != "" && version.Type == TypeRelease:
+			bumpedVersion, appliedRule = bumpSegment(version, len(version.Segments())-1)
+		default:
+			bumpedVersion, appliedRule = bumpSmart(version)
+		}
+	case BumpCalendar:
+		bumpedVersion, appliedRule, err = bumpCalendar(version, calver, opts.Date)
+	case BumpRevision:
+		if opts.Scheme.segments() < 4 {
+			return nil, "", fmt.Errorf("revision bump requires versions with 4 or more numeric segments (SetSegments, Scheme.Segments)")
+		}
+		bumpedVersion, appliedRule = bumpSegment(version, 3)
+	case BumpRelease:
+		bumpedVersion, appliedRule, err = bumpRelease(version)
+	case BumpBranch:
+		bumpedVersion, appliedRule, err = bumpBranch(version, opts)
+	case BumpAuto:
+		return nil, "", fmt.Errorf("auto bump chooses the bump from commits, use BumpFromCommits")
+	case BumpPrerelease:
+		labelBump, err := opts.Scheme.policy().prereleaseBump(opts.Scheme.labels())
+		if err != nil {
+			return nil, "", err
+		}
+		return bumpStep(version, labelBump, opts)
 	case BumpMajor:
 		bumpedVersion, appliedRule = bumpMajor(version)
 	case BumpMinor:
@@ -84,31 +227,64 @@ func Bump(versionStr string, bumpType BumpType) (*BumpResult, error) {
 	case BumpPatch:
 		bumpedVersion, appliedRule = bumpPatch(version)
 	case BumpPre:
-		bumpedVersion, appliedRule = bumpPrerelease(version, "pre")
+		bumpedVersion, appliedRule, err = bumpPrerelease(version, "pre", opts.Scheme)
 	case BumpAlpha:
-		bumpedVersion, appliedRule = bumpPrerelease(version, "alpha")
+		bumpedVersion, appliedRule, err = bumpPrerelease(version, "alpha", opts.Scheme)
 	case BumpBeta:
-		bumpedVersion, appliedRule = bumpPrerelease(version, "beta")
+		bumpedVersion, appliedRule, err = bumpPrerelease(version, "beta", opts.Scheme)
 	case BumpRc:
-		bumpedVersion, appliedRule = bumpPrerelease(version, "rc")
+		bumpedVersion, appliedRule, err = bumpPrerelease(version, "rc", opts.Scheme)
 	case BumpFix:
-		bumpedVersion, appliedRule = bumpPostrelease(version, "fix")
+		bumpedVersion, appliedRule = bumpPostrelease(version, "fix", opts.Scheme)
 	case BumpNext:
-		bumpedVersion, appliedRule = bumpPostrelease(version, "next")
+		bumpedVersion, appliedRule = bumpPostrelease(version, "next", opts.Scheme)
 	case BumpPost:
-		bumpedVersion, appliedRule = bumpPostrelease(version, "post")
+		bumpedVersion, appliedRule = bumpPostrelease(version, "post", opts.Scheme)
 	case BumpFeat:
-		bumpedVersion, appliedRule = bumpIntermediate(version, "feat")
+		bumpedVersion, appliedRule = bumpIntermediate(version, "feat", opts.Scheme)
 	default:
-		return nil, fmt.Errorf("unknown bump type: %v", bumpType)
+		label, ok := opts.Scheme.labels().bumpLabel(bumpType)
+		switch {
+		case ok && label.Type == TypePrerelease:
+			bumpedVersion, appliedRule, err = bumpPrerelease(version, label.Name, opts.Scheme)
+		case ok && label.Type == TypePostrelease:
+			bumpedVersion, appliedRule = bumpPostrelease(version, label.Name, opts.Scheme)
+		default:
+			return nil, "", fmt.Errorf("unknown bump type: %v", bumpType)
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	// Bumps that keep major.minor.patch keep the extra segments of an N-part version
+	keepExtra := bumpedVersion.Extra == "" && version.Extra != "" && bumpedVersion.Major == version.Major &&
+		bumpedVersion.Minor == version.Minor && bumpedVersion.Patch == version.Patch
+	if keepExtra {
+		bumpedVersion.Extra = version.Extra
 	}
+	bumpedVersion.scheme = opts.Scheme
+	switch {
+	case calver != nil:
+		bumpedVersion.Original = calver.core(bumpedVersion) + bumpedVersion.Prerelease + bumpedVersion.Postrelease + bumpedVersion.Intermediate
+	case keepExtra:
+		bumpedVersion.Original = fmt.Sprintf("%d.%d.%d%s%s%s%s", bumpedVersion.Major, bumpedVersion.Minor, bumpedVersion.Patch,
+			bumpedVersion.Extra, bumpedVersion.Prerelease, bumpedVersion.Postrelease, bumpedVersion.Intermediate)
+	}
+	return bumpedVersion, appliedRule, nil
+}
 
-	return &BumpResult{
-		OriginalVersion: version.Original,
-		BumpedVersion:   bumpedVersion.String(),
-		BumpType:        bumpType,
-		AppliedRule:     appliedRule,
-	}, nil
+// bumpPolicy performs the smart bump the bump policy declares for the type of a version
+func bumpPolicy(version *Version, spec BumpSpec, opts BumpOptions) (*Version, string, error) {
+	bumped, rules := version, make([]string, 0, 2)
+	for _, step := range spec.steps() {
+		var rule string
+		var err error
+		if bumped, rule, err = bumpStep(bumped, step, opts); err != nil {
+			return nil, "", fmt.Errorf("smart bump %s of %s version %s by bump policy: %v", spec, version.Type, version.Original, err)
+		}
+		rules = append(rules, rule)
+	}
+	return bumped, strings.Join(rules, ", then ") + " (bump policy " + spec.String() + " for " + version.Type.String() + " versions)", nil
 }
 
 // bumpSmart performs intelligent version bumping based on current version state
@@ -137,6 +313,45 @@ func bumpSmart(version *Version) (*Version, string) {
 	}
 }
 
+// bumpCalendar advances a calendar version to a date, the current date when zero
+func bumpCalendar(version *Version, calver *CalVer, date time.Time) (*Version, string, error) {
+	if calver == nil {
+		return nil, "", fmt.Errorf("calendar bump requires a calendar versioning scheme")
+	}
+	if date.IsZero() {
+		var err error
+		if date, err = CalVerDate(); err != nil {
+			return nil, "", err
+		}
+	}
+	return calver.next(version, date)
+}
+
+// bumpSegment increments a numeric segment after patch of an N-part version and
+// drops the segments after it, e.g. segment 3 of 1.2.3.4.5 -> 1.2.3.5
+func bumpSegment(version *Version, index int) (*Version, string) {
+	segments := version.Segments()
+	for len(segments) <= index {
+		segments = append(segments, 0)
+	}
+	segments[index]++
+
+	var extra strings.Builder
+	for _, segment := range segments[3 : index+1] {
+		extra.WriteByte('.')
+		extra.WriteString(strconv.Itoa(segment))
+	}
+	bumped := &Version{
+		Major: version.Major,
+		Minor: version.Minor,
+		Patch: version.Patch,
+		Extra: extra.String(),
+		Type:  TypeRelease,
+	}
+	bumped.Original = bumped.String()
+	return bumped, fmt.Sprintf("increment version segment %d", index+1)
+}
+
 // bumpMajor increments the major version and resets minor and patch
 func bumpMajor(version *Version) (*Version, string) {
 	return &Version{
@@ -170,58 +385,109 @@ func bumpPatch(version *Version) (*Version, string) {
 	}, "increment patch version"
 }
 
-// bumpPrerelease increments the prerelease version
-func bumpPrerelease(version *Version, identifier string) (*Version, string) {
+// bumpPrerelease bumps a version to a prerelease with the given label. The
+// prerelease lifecycle follows the label ranks (alpha -> beta -> pre -> rc):
+//   - a release, postrelease or intermediate version is converted to label.1
+//   - a prerelease with the same label increments its identifier (alpha.4 -> alpha.5)
+//   - a prerelease with a lower label is promoted to label.1 (alpha.4 -> beta.1)
+//   - a prerelease with a higher label is rejected, the result would be lower (rc.1 -> alpha.1)
+//
+// A prerelease is finalized with BumpRelease.
+func bumpPrerelease(version *Version, identifier string, scheme *Scheme) (*Version, string, error) {
 	if version.Type == TypePrerelease {
-		// Increment existing prerelease
-		bumped, _ := incrementPrerelease(version)
-		return bumped, "increment existing prerelease identifier"
+		current, _ := splitLabel(version.Prerelease)
+		switch c := scheme.labels().compareLabels(current, identifier); {
+		case c == 0:
+			// Increment existing prerelease
+			bumped, _ := incrementPrerelease(version)
+			return bumped, "increment existing prerelease identifier", nil
+		case c > 0:
+			return nil, "", fmt.Errorf("cannot bump prerelease %s back from %s to %s, use %s or release", version.Original, current, identifier, current)
+		}
+		// Promote to the higher label
+		bumped, _ := convertPrerelease(version, identifier, scheme)
+		return bumped, "promote prerelease from " + current + " to " + bumped.Prerelease[1:], nil
 	}
-	// Convert to prerelease
+	bumped, rule := convertPrerelease(version, identifier, scheme)
+	return bumped, rule, nil
+}
+
+// convertPrerelease converts a version to the prerelease label.1 of its core version,
+// the number is the identifier start of the bump policy of the scheme
+func convertPrerelease(version *Version, identifier string, scheme *Scheme) (*Version, string) {
+	identifier = startIdentifier(identifier, scheme)
 	return &Version{
 		Major:      version.Major,
 		Minor:      version.Minor,
 		Patch:      version.Patch,
 		Type:       TypePrerelease,
-		Prerelease: "~" + identifier + ".1",
-		Original:   fmt.Sprintf("%d.%d.%d~%s.1", version.Major, version.Minor, version.Patch, identifier),
-	}, "convert to prerelease with " + identifier + ".1"
+		Prerelease: "~" + identifier,
+		Original:   fmt.Sprintf("%d.%d.%d~%s", version.Major, version.Minor, version.Patch, identifier),
+	}, "convert to prerelease with " + identifier
+}
+
+// startIdentifier returns the first identifier of a label, label.1 unless the
+// bump policy of the scheme starts identifiers at another number
+func startIdentifier(label string, scheme *Scheme) string {
+	return label + "." + strconv.Itoa(scheme.policy().Start)
+}
+
+// bumpRelease finalizes a prerelease to the release of its core version
+// (1.2.3~rc.2 -> 1.2.3). Releases, postreleases and intermediate versions are
+// rejected, their release is not greater.
+func bumpRelease(version *Version) (*Version, string, error) {
+	switch version.Type {
+	case TypePrerelease:
+		return &Version{
+			Major:    version.Major,
+			Minor:    version.Minor,
+			Patch:    version.Patch,
+			Type:     TypeRelease,
+			Original: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch),
+		}, "finalize prerelease " + version.Prerelease[1:] + " to release", nil
+	case TypeRelease:
+		return nil, "", fmt.Errorf("version %s is already a release", version.Original)
+	default:
+		return nil, "", fmt.Errorf("cannot finalize %s version %s, its release is lower", version.Type, version.Original)
+	}
 }
 
 // bumpPostrelease increments the postrelease version
-func bumpPostrelease(version *Version, identifier string) (*Version, string) {
+func bumpPostrelease(version *Version, identifier string, scheme *Scheme) (*Version, string) {
 	if version.Type == TypePostrelease {
 		// Increment existing postrelease
 		bumped, _ := incrementPostrelease(version)
 		return bumped, "increment existing postrelease identifier"
 	}
 	// Convert to postrelease
+	identifier = startIdentifier(identifier, scheme)
 	return &Version{
 		Major:       version.Major,
 		Minor:       version.Minor,
 		Patch:       version.Patch,
 		Type:        TypePostrelease,
-		Postrelease: "." + identifier + ".1",
-		Original:    fmt.Sprintf("%d.%d.%d.%s.1", version.Major, version.Minor, version.Patch, identifier),
-	}, "convert to postrelease with " + identifier + ".1"
+		Postrelease: "." + identifier,
+		Original:    fmt.Sprintf("%d.%d.%d.%s", version.Major, version.Minor, version.Patch, identifier),
+	}, "convert to postrelease with " + identifier
 }
 
 // bumpIntermediate increments the intermediate version
-func bumpIntermediate(version *Version, identifier string) (*Version, string) {
+func bumpIntermediate(version *Version, identifier string, scheme *Scheme) (*Version, string) {
 	if version.Type == TypeIntermediate {
 		// Increment existing intermediate
 		bumped, _ := incrementIntermediate(version)
 		return bumped, "increment existing intermediate identifier"
 	}
 	// Convert to intermediate
+	identifier = startIdentifier(identifier, scheme)
 	return &Version{
 		Major:        version.Major,
 		Minor:        version.Minor,
 		Patch:        version.Patch,
 		Type:         TypeIntermediate,
-		Intermediate: "_" + identifier + ".1",
-		Original:     fmt.Sprintf("%d.%d.%d_%s.1", version.Major, version.Minor, version.Patch, identifier),
-	}, "convert to intermediate with " + identifier + ".1"
+		Intermediate: "_" + identifier,
+		Original:     fmt.Sprintf("%d.%d.%d_%s", version.Major, version.Minor, version.Patch, identifier),
+	}, "convert to intermediate with " + identifier
 }
 
 // incrementPrerelease increments an existing prerelease version
@@ -231,14 +497,8 @@ func incrementPrerelease(version *Version) (*Version, string) {
 	
 	// Find the last numeric part and increment it
 	// Look for patterns like .1, .1.2, _1, _1.2, etc.
-	re := regexp.MustCompile(`([._])([0-9]+)([^0-9]*)$`)
-	matches := re.FindStringSubmatch(identifier)
-	
-	if matches != nil {
+	if newIdentifier, ok := incrementLastNumeric(identifier); ok {
 		// Found a numeric suffix, increment it
-		num, _ := strconv.Atoi(matches[2])
-		newNum := strconv.Itoa(num + 1)
-		newIdentifier := re.ReplaceAllString(identifier, matches[1]+newNum+matches[3])
 		newPrerelease := "~" + newIdentifier
 		
 		return &Version{
@@ -270,14 +530,8 @@ func incrementPostrelease(version *Version) (*Version, string) {
 	
 	// Find the last numeric part and increment it
 	// Look for patterns like .1, .1.2, _1, _1.2, etc.
-	re := regexp.MustCompile(`([._])([0-9]+)([^0-9]*)$`)
-	matches := re.FindStringSubmatch(identifier)
-	
-	if matches != nil {
+	if newIdentifier, ok := incrementLastNumeric(identifier); ok {
 		// Found a numeric suffix, increment it
-		num, _ := strconv.Atoi(matches[2])
-		newNum := strconv.Itoa(num + 1)
-		newIdentifier := re.ReplaceAllString(identifier, matches[1]+newNum+matches[3])
 		newPostrelease := "." + newIdentifier
 		
 		return &Version{
@@ -309,14 +563,8 @@ func incrementIntermediate(version *Version) (*Version, string) {
 	
 	// Find the last numeric part and increment it
 	// Look for patterns like .1, .1.2, _1, _1.2, etc.
-	re := regexp.MustCompile(`([._])([0-9]+)([^0-9]*)$`)
-	matches := re.FindStringSubmatch(identifier)
-	
-	if matches != nil {
+	if newIdentifier, ok := incrementLastNumeric(identifier); ok {
 		// Found a numeric suffix, increment it
-		num, _ := strconv.Atoi(matches[2])
-		newNum := strconv.Itoa(num + 1)
-		newIdentifier := re.ReplaceAllString(identifier, matches[1]+newNum+matches[3])
 		newIntermediate := "_" + newIdentifier
 		
 		return &Version{
@@ -341,8 +589,33 @@ func incrementIntermediate(version *Version) (*Version, string) {
 	}, "add intermediate numeric identifier"
 }
 
-// ParseBumpType parses a bump type string
+// incrementLastNumeric increments the last numeric part of an identifier
+// when it follows a '.' or '_' delimiter, e.g. alpha.1_feature -> alpha.2_feature
+func incrementLastNumeric(identifier string) (string, bool) {
+	end := len(identifier)
+	for end > 0 && !isDigit(identifier[end-1]) {
+		end--
+	}
+	start := end
+	for start > 0 && isDigit(identifier[start-1]) {
+		start--
+	}
+	if start == end || start == 0 || (identifier[start-1] != '.' && identifier[start-1] != '_') {
+		return identifier, false
+	}
+	num, _ := strconv.Atoi(identifier[start:end])
+	return identifier[:start] + strconv.Itoa(num+1) + identifier[end:], true
+}
+
+// ParseBumpType parses a bump type string. Besides the built-in bump types it
+// accepts the labels of the current label registry, a label that has the name
+// of a built-in bump type (e.g. patch) is selected with the "label:" prefix.
 func ParseBumpType(bumpTypeStr string) (BumpType, error) {
+	return parseBumpType(bumpTypeStr, CurrentLabelRegistry())
+}
+
+// parseBumpType parses a bump type string with the labels of a registry
+func parseBumpType(bumpTypeStr string, labels *LabelRegistry) (BumpType, error) {
 	switch strings.ToLower(bumpTypeStr) {
 	case "major":
 		return BumpMajor, nil
@@ -368,7 +641,23 @@ func ParseBumpType(bumpTypeStr string) (BumpType, error) {
 		return BumpFeat, nil
 	case "smart":
 		return BumpSmart, nil
+	case "calendar":
+		return BumpCalendar, nil
+	case "revision", "build":
+		return BumpRevision, nil
+	case "release", "finalize":
+		return BumpRelease, nil
+	case "auto":
+		return BumpAuto, nil
+	case "prerelease":
+		return BumpPrerelease, nil
+	case "branch":
+		return BumpBranch, nil
 	default:
+		name := strings.TrimPrefix(strings.ToLower(bumpTypeStr), "label:")
+		if bumpType, ok := labels.bumpType(name); ok {
+			return bumpType, nil
+		}
 		return BumpSmart, fmt.Errorf("unknown bump type: %s", bumpTypeStr)
 	}
 }
\ No newline at end of file
@@ -90,8 +90,8 @@ func TestBumpAlpha(t *testing.T) {
 	}{
 		{"release to prerelease", "1.2.3", "1.2.3~alpha.1"},
 		{"increment prerelease", "1.2.3~alpha.1", "1.2.3~alpha.2"},
-		{"increment prerelease with complex suffix", "1.2.3~alpha.1_feature", "1.2.3~alpha_feature.1"},
-		{"increment prerelease with multiple numbers", "1.2.3~alpha.1.2", "1.2.3~alpha.3"},
+		{"increment prerelease with complex suffix", "1.2.3~alpha.1_feature", "1.2.3~alpha.2_feature"},
+		{"increment prerelease with multiple numbers", "1.2.3~alpha.1.2", "1.2.3~alpha.1.3"},
 		{"prerelease with v prefix", "v1.2.3", "1.2.3~alpha.1"},
 	}
 
@@ -116,8 +116,8 @@ func TestBumpFix(t *testing.T) {
 	}{
 		{"release to postrelease", "1.2.3", "1.2.3.fix.1"},
 		{"increment postrelease", "1.2.3.fix.1", "1.2.3.fix.2"},
-		{"increment postrelease with complex suffix", "1.2.3.fix.1_feature", "1.2.3.fix_feature.1"},
-		{"increment postrelease with multiple numbers", "1.2.3.fix.1.2", "1.2.3.fix.3"},
+		{"increment postrelease with complex suffix", "1.2.3.fix.1_feature", "1.2.3.fix.2_feature"},
+		{"increment postrelease with multiple numbers", "1.2.3.fix.1.2", "1.2.3.fix.1.3"},
 		{"postrelease with v prefix", "v1.2.3", "1.2.3.fix.1"},
 	}
 
@@ -142,8 +142,8 @@ func TestBumpFeat(t *testing.T) {
 	}{
 		{"release to intermediate", "1.2.3", "1.2.3_feat.1"},
 		{"increment intermediate", "1.2.3_feat.1", "1.2.3_feat.2"},
-		{"increment intermediate with complex suffix", "1.2.3_feat.1_dev", "1.2.3_feat_dev.1"},
-		{"increment intermediate with multiple numbers", "1.2.3_feat.1.2", "1.2.3_feat.3"},
+		{"increment intermediate with complex suffix", "1.2.3_feat.1_dev", "1.2.3_feat.2_dev"},
+		{"increment intermediate with multiple numbers", "1.2.3_feat.1.2", "1.2.3_feat.1.3"},
 		{"intermediate with v prefix", "v1.2.3", "1.2.3_feat.1"},
 	}
 
@@ -205,6 +205,8 @@ func TestParseBumpType(t *testing.T) {
 		{"post", "post", BumpPost, false},
 		{"feat", "feat", BumpFeat, false},
 		{"smart", "smart", BumpSmart, false},
+		{"release", "release", BumpRelease, false},
+		{"finalize", "finalize", BumpRelease, false},
 		{"uppercase", "MAJOR", BumpMajor, false},
 		{"mixed case", "Major", BumpMajor, false},
 		{"invalid type", "invalid", BumpSmart, true},
@@ -274,6 +276,7 @@ func TestBumpTypeString(t *testing.T) {
 		{BumpPost, "post"},
 		{BumpFeat, "feat"},
 		{BumpSmart, "smart"},
+		{BumpRelease, "release"},
 		{BumpType(999), "unknown"},
 	}
 
@@ -285,4 +288,123 @@ func TestBumpTypeString(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestBumpBuildMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		bumpType  BumpType
+		dropBuild bool
+		expected  string
+	}{
+		{"carry build on smart bump", "1.2.3+git.abc123", BumpSmart, false, "1.2.4+git.abc123"},
+		{"carry build on prerelease bump", "1.2.3~rc.1+build.42", BumpSmart, false, "1.2.3~rc.2+build.42"},
+		{"carry build on major bump", "1.2.3+build.42", BumpMajor, false, "2.0.0+build.42"},
+		{"drop build on request", "1.2.3~rc.1+build.42", BumpRc, true, "1.2.3~rc.2"},
+		{"drop without build", "1.2.3", BumpPatch, true, "1.2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := BumpWithOptions(tt.input, tt.bumpType, BumpOptions{DropBuild: tt.dropBuild})
+			if err != nil {
+				t.Fatalf("Bump failed: %v", err)
+			}
+			if result.BumpedVersion != tt.expected {
+				t.Errorf("BumpWithOptions(%s, %s) = %s, want %s", tt.input, tt.bumpType, result.BumpedVersion, tt.expected)
+			}
+			if !IsValid(result.BumpedVersion) {
+				t.Errorf("Bumped version %s is not valid", result.BumpedVersion)
+			}
+		})
+	}
+}
+
+func TestIncrementLastNumeric(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"alpha.1", "alpha.2", true},
+		{"alpha.9", "alpha.10", true},
+		{"alpha.1.2", "alpha.1.3", true},
+		{"alpha.1_feature", "alpha.2_feature", true},
+		{"feat_x.1_y", "feat_x.2_y", true},
+		{"alpha", "alpha", false},
+		{"alpha_x", "alpha_x", false},
+		{"1", "1", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, ok := incrementLastNumeric(test.input)
+			if result != test.expected || ok != test.ok {
+				t.Errorf("incrementLastNumeric(%s) = %s, %v, want %s, %v", test.input, result, ok, test.expected, test.ok)
+			}
+		})
+	}
+}
+
+func TestBumpPrereleaseLifecycle(t *testing.T) {
+	tests := []struct {
+		input    string
+		bumpType BumpType
+		expected string
+		hasError bool
+	}{
+		{"1.2.3~alpha.4", BumpAlpha, "1.2.3~alpha.5", false},
+		{"1.2.3~alpha.4", BumpBeta, "1.2.3~beta.1", false},
+		{"1.2.3~alpha.4", BumpRc, "1.2.3~rc.1", false},
+		{"1.2.3~beta.2", BumpPre, "1.2.3~pre.1", false},
+		{"1.2.3~pre.1", BumpRc, "1.2.3~rc.1", false},
+		{"1.2.3~rc.2", BumpRelease, "1.2.3", false},
+		{"1.2.3~alpha.1+build.5", BumpRelease, "1.2.3+build.5", false},
+		{"v1.2.3-rc.1", BumpRelease, "1.2.3", false},
+		{"1.2.3~rc.1", BumpAlpha, "", true},
+		{"1.2.3~rc.1", BumpPre, "", true},
+		{"1.2.3", BumpRelease, "", true},
+		{"1.2.3.fix.1", BumpRelease, "", true},
+		{"1.2.3_feat.1", BumpRelease, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"_"+test.bumpType.String(), func(t *testing.T) {
+			result, err := Bump(test.input, test.bumpType)
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Bump(%s, %s) = %s, want error", test.input, test.bumpType, result.BumpedVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump(%s, %s) failed: %v", test.input, test.bumpType, err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("Bump(%s, %s) = %s, want %s", test.input, test.bumpType, result.BumpedVersion, test.expected)
+			}
+			before, _ := Parse(test.input)
+			if Compare(before, result.Version) >= 0 {
+				t.Errorf("Bump(%s, %s) = %s is not greater", test.input, test.bumpType, result.BumpedVersion)
+			}
+		})
+	}
+}
+
+func TestBumpPrereleaseLifecycleLabels(t *testing.T) {
+	useLabels(t, orgLabels...)
+	dev, _ := ParseBumpType("dev")
+	preview, _ := ParseBumpType("preview")
+
+	if result, err := Bump("1.2.3~dev.3", preview); err != nil || result.BumpedVersion != "1.2.3~preview.1" {
+		t.Errorf("Bump(1.2.3~dev.3, preview) = %v, %v, want 1.2.3~preview.1", result, err)
+	}
+	if _, err := Bump("1.2.3~preview.1", dev); err == nil {
+		t.Errorf("Expected error for the backwards transition preview -> dev")
+	}
+
+	useSegments(t, 4)
+	if result, err := Bump("1.2.3.4~rc.1", BumpRelease); err != nil || result.BumpedVersion != "1.2.3.4" {
+		t.Errorf("Bump(1.2.3.4~rc.1, release) = %v, %v, want 1.2.3.4", result, err)
+	}
+}
@@ -0,0 +1,139 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BumpSpec is a bump of up to two steps: a core bump of the version followed by
+// a label bump, e.g. minor+alpha (1.2.3 -> 1.3.0~alpha.1). A single step spec
+// leaves the other part BumpNone.
+type BumpSpec struct {
+	Core  BumpType // major, minor, patch, revision, calendar, smart, release, auto, branch or BumpNone
+	Label BumpType // pre, alpha, beta, rc, prerelease, fix, next, post, feat, a custom label or BumpNone
+}
+
+// String returns the spec as accepted by ParseBumpSpec (minor+alpha)
+func (s BumpSpec) String() string {
+	switch {
+	case s.Core == BumpNone:
+		return s.Label.String()
+	case s.Label == BumpNone:
+		return s.Core.String()
+	default:
+		return s.Core.String() + "+" + s.Label.String()
+	}
+}
+
+// ParseBumpSpec parses a bump type or a compound bump core+label, where core is
+// major, minor, patch, revision or calendar and label is a prerelease label
+// (minor+alpha, patch+rc, major+label:dev)
+func ParseBumpSpec(specStr string) (BumpSpec, error) {
+	return parseBumpSpec(specStr, CurrentLabelRegistry())
+}
+
+// parseBumpSpec parses a bump spec with the labels of a registry
+func parseBumpSpec(specStr string, labels *LabelRegistry) (BumpSpec, error) {
+	coreStr, labelStr, compound := strings.Cut(specStr, "+")
+	if !compound {
+		bumpType, err := parseBumpType(specStr, labels)
+		if err != nil {
+			return BumpSpec{}, err
+		}
+		if isCoreBump(bumpType) || isWholeBump(bumpType) {
+			return BumpSpec{Core: bumpType, Label: BumpNone}, nil
+		}
+		return BumpSpec{Core: BumpNone, Label: bumpType}, nil
+	}
+
+	core, err := parseBumpType(coreStr, labels)
+	if err != nil {
+		return BumpSpec{}, err
+	}
+	label, err := parseBumpType(labelStr, labels)
+	if err != nil {
+		return BumpSpec{}, err
+	}
+	spec := BumpSpec{Core: core, Label: label}
+	if err := spec.validate(labels); err != nil {
+		return BumpSpec{}, err
+	}
+	return spec, nil
+}
+
+// validate checks that the spec has a valid core and label part, custom
+// label bumps must be labels of the registry
+func (s BumpSpec) validate(labels *LabelRegistry) error {
+	switch {
+	case s.Core == BumpNone && s.Label == BumpNone:
+		return fmt.Errorf("empty bump spec")
+	case s.Core == BumpNone || s.Label == BumpNone:
+		if s.Core != BumpNone && !isCoreBump(s.Core) && !isWholeBump(s.Core) {
+			return fmt.Errorf("invalid core bump '%s' (supported: major, minor, patch, revision, calendar, smart, release, auto, branch)", s.Core)
+		}
+		if _, ok := labelBumpType(s.Label, labels); s.Label != BumpNone && !ok {
+			return fmt.Errorf("invalid label bump '%s'", s.Label)
+		}
+		return nil
+	case !isCoreBump(s.Core):
+		return fmt.Errorf("compound bump %s: '%s' is not a core bump (supported: major, minor, patch, revision, calendar)", s, s.Core)
+	}
+	if labelType, ok := labelBumpType(s.Label, labels); !ok || labelType != TypePrerelease {
+		return fmt.Errorf("compound bump %s: '%s' is not a prerelease label", s, s.Label)
+	}
+	return nil
+}
+
+// steps returns the bump types of the spec in the order they are applied
+func (s BumpSpec) steps() []BumpType {
+	steps := make([]BumpType, 0, 2)
+	if s.Core != BumpNone {
+		steps = append(steps, s.Core)
+	}
+	if s.Label != BumpNone {
+		steps = append(steps, s.Label)
+	}
+	return steps
+}
+
+// BumpWithSpec bumps a version by the core part of the spec and then by its label
+// part: 1.2.3 with minor+alpha gives 1.3.0~alpha.1. The applied rule describes both
+// steps and BumpType of the result is the last step. Build metadata and the style
+// of the original version are handled as by BumpWithOptions.
+func BumpWithSpec(versionStr string, spec BumpSpec, opts BumpOptions) (*BumpResult, error) {
+	if err := spec.validate(opts.Scheme.labels()); err != nil {
+		return nil, err
+	}
+	return bumpSteps(versionStr, opts, spec.steps()...)
+}
+
+// isCoreBump reports whether a bump type changes the numeric core of a version
+func isCoreBump(bt BumpType) bool {
+	switch bt {
+	case BumpMajor, BumpMinor, BumpPatch, BumpRevision, BumpCalendar:
+		return true
+	}
+	return false
+}
+
+// isWholeBump reports whether a bump type decides the whole bump and is not
+// combined with a label (smart, release, auto, branch)
+func isWholeBump(bt BumpType) bool {
+	return bt == BumpSmart || bt == BumpRelease || bt == BumpAuto || bt == BumpBranch
+}
+
+// labelBumpType returns the version type a label bump converts a version to
+func labelBumpType(bt BumpType, labels *LabelRegistry) (Type, bool) {
+	switch bt {
+	case BumpPre, BumpAlpha, BumpBeta, BumpRc, BumpPrerelease:
+		return TypePrerelease, true
+	case BumpFix, BumpNext, BumpPost:
+		return TypePostrelease, true
+	case BumpFeat:
+		return TypeIntermediate, true
+	}
+	if label, ok := labels.bumpLabel(bt); ok {
+		return label.Type, true
+	}
+	return TypeInvalid, false
+}
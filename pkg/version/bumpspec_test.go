@@ -0,0 +1,114 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBumpSpec(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected BumpSpec
+		wantErr  bool
+	}{
+		{"minor+alpha", BumpSpec{Core: BumpMinor, Label: BumpAlpha}, false},
+		{"Major+RC", BumpSpec{Core: BumpMajor, Label: BumpRc}, false},
+		{"patch+pre", BumpSpec{Core: BumpPatch, Label: BumpPre}, false},
+		{"minor", BumpSpec{Core: BumpMinor, Label: BumpNone}, false},
+		{"smart", BumpSpec{Core: BumpSmart, Label: BumpNone}, false},
+		{"release", BumpSpec{Core: BumpRelease, Label: BumpNone}, false},
+		{"release+rc", BumpSpec{}, true},
+		{"beta", BumpSpec{Core: BumpNone, Label: BumpBeta}, false},
+		{"fix", BumpSpec{Core: BumpNone, Label: BumpFix}, false},
+		{"minor+fix", BumpSpec{}, true},
+		{"smart+alpha", BumpSpec{}, true},
+		{"alpha+minor", BumpSpec{}, true},
+		{"minor+", BumpSpec{}, true},
+		{"minor+gamma", BumpSpec{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			spec, err := ParseBumpSpec(test.input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseBumpSpec(%s) error = %v, wantErr %v", test.input, err, test.wantErr)
+			}
+			if !test.wantErr && spec != test.expected {
+				t.Errorf("ParseBumpSpec(%s) = %+v, want %+v", test.input, spec, test.expected)
+			}
+			if !test.wantErr && !strings.EqualFold(spec.String(), test.input) {
+				t.Errorf("BumpSpec.String() = %s, want %s", spec, test.input)
+			}
+		})
+	}
+}
+
+func TestBumpWithSpec(t *testing.T) {
+	tests := []struct {
+		version  string
+		spec     string
+		expected string
+		rules    []string // parts of the applied rule
+	}{
+		{"1.2.3", "minor+alpha", "1.3.0~alpha.1", []string{"increment minor version", "then convert to prerelease with alpha.1"}},
+		{"1.2.3", "major+rc", "2.0.0~rc.1", []string{"increment major version", "then convert to prerelease with rc.1"}},
+		{"1.2.3", "patch+beta", "1.2.4~beta.1", []string{"increment patch version", "then convert to prerelease with beta.1"}},
+		{"1.3.0~alpha.2", "minor+alpha", "1.4.0~alpha.1", nil},
+		{"1.2.3+build.5", "minor+rc", "1.3.0~rc.1+build.5", []string{"then convert to prerelease with rc.1, carry build metadata +build.5"}},
+		{"1.2.3", "minor", "1.3.0", []string{"increment minor version"}},
+		{"1.2.3", "alpha", "1.2.3~alpha.1", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+"_"+test.spec, func(t *testing.T) {
+			spec, err := ParseBumpSpec(test.spec)
+			if err != nil {
+				t.Fatalf("ParseBumpSpec(%s) failed: %v", test.spec, err)
+			}
+			result, err := BumpWithSpec(test.version, spec, BumpOptions{})
+			if err != nil {
+				t.Fatalf("BumpWithSpec(%s, %s) failed: %v", test.version, test.spec, err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("BumpWithSpec(%s, %s) = %s, want %s", test.version, test.spec, result.BumpedVersion, test.expected)
+			}
+			for _, rule := range test.rules {
+				if !strings.Contains(result.AppliedRule, rule) {
+					t.Errorf("AppliedRule = %q, want it to contain %q", result.AppliedRule, rule)
+				}
+			}
+		})
+	}
+
+	// A single step spec bumps exactly like BumpWithOptions
+	single, _ := BumpWithOptions("1.2.3~rc.1", BumpSmart, BumpOptions{})
+	spec, _ := BumpWithSpec("1.2.3~rc.1", BumpSpec{Core: BumpSmart, Label: BumpNone}, BumpOptions{})
+	if *single.Version != *spec.Version || single.AppliedRule != spec.AppliedRule {
+		t.Errorf("BumpWithSpec(smart) = %+v, want %+v", spec, single)
+	}
+
+	if _, err := BumpWithSpec("1.2.3", BumpSpec{Core: BumpNone, Label: BumpNone}, BumpOptions{}); err == nil {
+		t.Errorf("Expected error for an empty bump spec")
+	}
+
+	result, err := BumpWithSpec("v1.2.3", BumpSpec{Core: BumpMinor, Label: BumpRc}, BumpOptions{})
+	if err != nil || result.Version.ToGitTag() != "v1.3.0-rc.1" || result.Version.Format(result.Version.Style) != "v1.3.0~rc.1" {
+		t.Errorf("BumpWithSpec(v1.2.3, minor+rc) = %v, %v, want v1.3.0-rc.1 as git tag", result, err)
+	}
+}
+
+func TestBumpWithSpecSchemes(t *testing.T) {
+	useLabels(t, orgLabels...)
+	spec, err := ParseBumpSpec("minor+label:dev")
+	if err != nil {
+		t.Fatalf("ParseBumpSpec(minor+label:dev) failed: %v", err)
+	}
+	if result, err := BumpWithSpec("1.2.3", spec, BumpOptions{}); err != nil || result.BumpedVersion != "1.3.0~dev.1" {
+		t.Errorf("BumpWithSpec(1.2.3, minor+dev) = %v, %v, want 1.3.0~dev.1", result, err)
+	}
+
+	useSegments(t, 4)
+	if result, err := BumpWithSpec("1.2.3.4", BumpSpec{Core: BumpRevision, Label: BumpRc}, BumpOptions{}); err != nil || result.BumpedVersion != "1.2.3.5~rc.1" {
+		t.Errorf("BumpWithSpec(1.2.3.4, revision+rc) = %v, %v, want 1.2.3.5~rc.1", result, err)
+	}
+}
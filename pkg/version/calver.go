@@ -0,0 +1,288 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CalVer is a calendar versioning scheme (https://calver.org) such as
+// YYYY.MM.DD, YY.0M.MICRO or YYYY.WW. Its segments take the place of major,
+// minor and patch, so calendar versions order like any other version and may
+// carry prerelease, postrelease and intermediate identifiers.
+type CalVer struct {
+	format   string
+	segments []calverSegment
+}
+
+// calverSegment is one dot separated segment of a calendar versioning format
+type calverSegment struct {
+	token    string // Format token, e.g. YYYY or 0M
+	field    byte   // 'Y' year, 'M' month, 'W' week, 'D' day or 'N' micro
+	rank     int    // Significance, segments are ordered from year to micro
+	short    bool   // Year counted from 2000 (YY, 0Y)
+	padded   bool   // Zero padded to two digits (0Y, 0M, 0W, 0D)
+	expected string // Description used in parse errors
+}
+
+// calverTokens are the supported format tokens
+var calverTokens = map[string]calverSegment{
+	"YYYY":  {token: "YYYY", field: 'Y', expected: "four digit year"},
+	"YY":    {token: "YY", field: 'Y', short: true, expected: "year since 2000 without leading zeros"},
+	"0Y":    {token: "0Y", field: 'Y', short: true, padded: true, expected: "two digit year since 2000"},
+	"MM":    {token: "MM", field: 'M', rank: 1, expected: "month 1-12 without leading zeros"},
+	"0M":    {token: "0M", field: 'M', rank: 1, padded: true, expected: "two digit month 01-12"},
+	"WW":    {token: "WW", field: 'W', rank: 1, expected: "ISO week 1-53 without leading zeros"},
+	"0W":    {token: "0W", field: 'W', rank: 1, padded: true, expected: "two digit ISO week 01-53"},
+	"DD":    {token: "DD", field: 'D', rank: 2, expected: "day of month without leading zeros"},
+	"0D":    {token: "0D", field: 'D', rank: 2, padded: true, expected: "two digit day of month"},
+	"MICRO": {token: "MICRO", field: 'N', rank: 3, expected: "micro number without leading zeros"},
+}
+
+// ParseCalVer parses a calendar versioning format. Segments are separated by
+// dots, start with a year and are ordered year, month or week, day, micro:
+//   - YYYY full year (2024), YY and 0Y year since 2000 (24, 06 for 0Y)
+//   - MM and 0M month, WW and 0W ISO week, DD and 0D day of month (0 prefix: zero padded)
+//   - MICRO counter of versions released on the same date, starting at 0
+//
+// A format has at most three segments.
+func ParseCalVer(format string) (*CalVer, error) {
+	tokens := strings.Split(format, ".")
+	if len(tokens) > 3 {
+		return nil, fmt.Errorf("invalid calver format %s: at most 3 segments are supported", format)
+	}
+
+	c := &CalVer{format: format}
+	for i, token := range tokens {
+		segment, ok := calverTokens[token]
+		if !ok {
+			return nil, fmt.Errorf("invalid calver format %s: unknown segment '%s' (supported: YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MICRO)", format, token)
+		}
+		switch {
+		case i == 0 && segment.field != 'Y':
+			return nil, fmt.Errorf("invalid calver format %s: the first segment must be a year", format)
+		case i > 0 && segment.rank <= c.segments[i-1].rank:
+			return nil, fmt.Errorf("invalid calver format %s: segments must be ordered year, month or week, day, micro", format)
+		case segment.field == 'D' && c.segment('M') < 0:
+			return nil, fmt.Errorf("invalid calver format %s: a day segment requires a month segment", format)
+		}
+		c.segments = append(c.segments, segment)
+	}
+	return c, nil
+}
+
+// String returns the calendar versioning format
+func (c *CalVer) String() string {
+	return c.format
+}
+
+// Parse parses a version string whose core follows the calendar versioning scheme
+func (c *CalVer) Parse(versionStr string) (*Version, error) {
+	version := &Version{}
+	if err := parseInto(version, versionStr, c, nil); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// currentCalVer is the scheme used by Parse and Bump, nil for major.minor.patch versions
+var currentCalVer atomic.Pointer[CalVer]
+
+// CurrentCalVer returns the calendar versioning scheme used by Parse and Bump,
+// nil when versions use major.minor.patch
+func CurrentCalVer() *CalVer {
+	return currentCalVer.Load()
+}
+
+// SetCalVer sets the calendar versioning scheme used by Parse and Bump.
+// A nil scheme restores major.minor.patch versions.
+func SetCalVer(c *CalVer) {
+	currentCalVer.Store(c)
+}
+
+// CalVerDate returns the date used by calendar bumps in UTC: SOURCE_DATE_EPOCH
+// (https://reproducible-builds.org/specs/source-date-epoch/) when set, the current time otherwise
+func CalVerDate() (time.Time, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH '%s': must be a number of seconds", epoch)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Now().UTC(), nil
+}
+
+// segment returns the index of the segment of a field, -1 when the format has none
+func (c *CalVer) segment(field byte) int {
+	for i, segment := range c.segments {
+		if segment.field == field {
+			return i
+		}
+	}
+	return -1
+}
+
+// core returns the version core of v in the calendar versioning format
+func (c *CalVer) core(v *Version) string {
+	values := [3]int{v.Major, v.Minor, v.Patch}
+	var b strings.Builder
+	for i, segment := range c.segments {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		if segment.padded {
+			fmt.Fprintf(&b, "%02d", values[i])
+		} else {
+			b.WriteString(strconv.Itoa(values[i]))
+		}
+	}
+	return b.String()
+}
+
+// year returns the calendar year of a year segment value
+func (s calverSegment) year(value int) int {
+	if s.short {
+		return 2000 + value
+	}
+	return value
+}
+
+// validDigits reports whether a segment value is written with the padding of its token
+func (s calverSegment) validDigits(digits string) bool {
+	switch {
+	case s.token == "YYYY":
+		return len(digits) == 4 && digits[0] != '0'
+	case s.padded:
+		return len(digits) == 2 || (len(digits) > 2 && digits[0] != '0')
+	default:
+		return digits == "0" || digits[0] != '0'
+	}
+}
+
+// checkDate returns the index of the first segment that is not a valid date
+// component together with a description of the expected value, -1 when valid
+func (c *CalVer) checkDate(values [3]int) (int, string) {
+	year := c.segments[0].year(values[0])
+	for i, segment := range c.segments {
+		switch segment.field {
+		case 'M':
+			if values[i] < 1 || values[i] > 12 {
+				return i, "month 1-12"
+			}
+		case 'W':
+			if _, weeks := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek(); values[i] < 1 || values[i] > weeks {
+				return i, fmt.Sprintf("ISO week 1-%d of %d", weeks, year)
+			}
+		case 'D':
+			month := time.Month(values[c.segment('M')])
+			if days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); values[i] < 1 || values[i] > days {
+				return i, fmt.Sprintf("day 1-%d of %d-%02d", days, year, month)
+			}
+		}
+	}
+	return -1, ""
+}
+
+// date returns the first day of the calendar date of a calendar version: the
+// first of the month without a day segment, the Monday of the ISO week for
+// week formats and January 1 for a year alone
+func (c *CalVer) date(v *Version) time.Time {
+	values := [3]int{v.Major, v.Minor, v.Patch}
+	year, month, day := c.segments[0].year(values[0]), 1, 1
+	if i := c.segment('W'); i >= 0 {
+		// ISO week 1 is the week of January 4
+		jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, 7*(values[i]-1))
+	}
+	if i := c.segment('M'); i >= 0 {
+		month = values[i]
+	}
+	if i := c.segment('D'); i >= 0 {
+		day = values[i]
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// calverCore parses the version core of a calendar version into major, minor and patch
+func (p *versionParser) calverCore(v *Version) *ParseError {
+	var values, starts [3]int
+	for i, segment := range p.calver.segments {
+		if i > 0 {
+			if err := p.expect('.', "'.' after "+p.calver.segments[i-1].token, "<calver-core>"); err != nil {
+				return err
+			}
+		}
+		starts[i] = p.pos
+		n, err := p.number(segment.expected, "<"+segment.token+">")
+		if err != nil {
+			return err
+		}
+		if !segment.validDigits(p.input[starts[i]:p.pos]) {
+			p.pos = starts[i]
+			return p.fail(segment.expected, "<"+segment.token+">")
+		}
+		values[i] = n
+	}
+
+	if i, expected := p.calver.checkDate(values); i >= 0 {
+		p.pos = starts[i]
+		return p.fail(expected, "<"+p.calver.segments[i].token+">")
+	}
+	v.Major, v.Minor, v.Patch = values[0], values[1], values[2]
+	return nil
+}
+
+// next returns the release following v on a date. Year, month, week and day
+// segments are taken from the date, the micro segment counts the releases of
+// the same date and starts at 0. A prerelease of the date is released as is.
+func (c *CalVer) next(v *Version, date time.Time) (*Version, string, error) {
+	current := [3]int{v.Major, v.Minor, v.Patch}
+	var values [3]int
+	sameDate := true
+	for i, segment := range c.segments {
+		switch segment.field {
+		case 'Y':
+			values[i] = date.Year()
+			if c.segment('W') >= 0 {
+				values[i], _ = date.ISOWeek()
+			}
+			if segment.short {
+				values[i] -= 2000
+			}
+		case 'M':
+			values[i] = int(date.Month())
+		case 'W':
+			_, values[i] = date.ISOWeek()
+		case 'D':
+			values[i] = date.Day()
+		case 'N':
+			continue
+		}
+		sameDate = sameDate && values[i] == current[i]
+	}
+
+	micro := c.segment('N')
+	rule := "set calendar date " + date.Format("2006-01-02")
+	switch {
+	case sameDate && v.Type == TypePrerelease:
+		values = current
+		rule = "release prerelease of calendar date " + date.Format("2006-01-02")
+	case sameDate && micro >= 0:
+		values[micro] = current[micro] + 1
+		rule = "increment micro for calendar date " + date.Format("2006-01-02")
+	case sameDate:
+		return nil, "", fmt.Errorf("version %s already has calendar date %s and format %s has no MICRO segment", v, date.Format("2006-01-02"), c)
+	}
+
+	bumped := &Version{Major: values[0], Minor: values[1], Patch: values[2], Type: TypeRelease}
+	bumped.Original = c.core(bumped)
+	if Compare(bumped, v) <= 0 {
+		return nil, "", fmt.Errorf("calendar date %s gives %s which is not greater than %s", date.Format("2006-01-02"), bumped, v)
+	}
+	return bumped, rule, nil
+}
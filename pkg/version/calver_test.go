@@ -0,0 +1,235 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useCalVer sets a calendar versioning scheme for the duration of a test
+func useCalVer(t *testing.T, format string) *CalVer {
+	t.Helper()
+	c, err := ParseCalVer(format)
+	if err != nil {
+		t.Fatalf("ParseCalVer(%s) failed: %v", format, err)
+	}
+	SetCalVer(c)
+	t.Cleanup(func() { SetCalVer(nil) })
+	return c
+}
+
+func TestParseCalVer(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"YYYY.MM.DD", false},
+		{"YY.0M.MICRO", false},
+		{"YYYY.WW", false},
+		{"0Y.0W.MICRO", false},
+		{"YYYY.MICRO", false},
+		{"YYYY", false},
+		{"", true},
+		{"MM.YYYY", true},
+		{"YYYY.DD", true},
+		{"YYYY.MM.WW", true},
+		{"YYYY.MICRO.MM", true},
+		{"YYYY.MM.DD.MICRO", true},
+		{"YYYY.mm", true},
+		{"YYYY.MONTH", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			c, err := ParseCalVer(test.format)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseCalVer(%s) error = %v, wantErr %v", test.format, err, test.wantErr)
+			}
+			if err == nil && c.String() != test.format {
+				t.Errorf("String() = %s, want %s", c, test.format)
+			}
+		})
+	}
+}
+
+func TestCalVerParse(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected *Version
+		offset   int // offset of the parse error, -1 when valid
+	}{
+		{"YYYY.MM.DD", "2024.5.17", &Version{Major: 2024, Minor: 5, Patch: 17, Type: TypeRelease, Original: "2024.5.17"}, -1},
+		{"YYYY.MM.DD", "v2024.2.29", &Version{Major: 2024, Minor: 2, Patch: 29, Type: TypeRelease, Original: "v2024.2.29", Style: Style{Prefix: true}}, -1},
+		{"YYYY.MM.DD", "2024.5.17-rc.1", &Version{Major: 2024, Minor: 5, Patch: 17, Type: TypePrerelease, Prerelease: "~rc.1", Original: "2024.5.17~rc.1", Style: Style{Hyphen: true}}, -1},
+		{"YYYY.MM.DD", "2023.2.29", nil, 7},
+		{"YYYY.MM.DD", "2024.4.31", nil, 7},
+		{"YYYY.MM.DD", "2024.13.1", nil, 5},
+		{"YYYY.MM.DD", "2024.05.17", nil, 5},
+		{"YYYY.MM.DD", "24.5.17", nil, 0},
+		{"YYYY.MM.DD", "1.2.3", nil, 0},
+		{"YY.0M.MICRO", "24.05.0", &Version{Major: 24, Minor: 5, Patch: 0, Type: TypeRelease, Original: "24.05.0"}, -1},
+		{"YY.0M.MICRO", "24.12.3.fix.1", &Version{Major: 24, Minor: 12, Patch: 3, Type: TypePostrelease, Postrelease: ".fix.1", Original: "24.12.3.fix.1"}, -1},
+		{"YY.0M.MICRO", "24.5.0", nil, 3},
+		{"YY.0M.MICRO", "24.00.1", nil, 3},
+		{"YY.0M.MICRO", "24.05.01", nil, 6},
+		{"YY.0M.MICRO", "024.05.1", nil, 0},
+		{"YYYY.WW", "2020.53", &Version{Major: 2020, Minor: 53, Type: TypeRelease, Original: "2020.53"}, -1},
+		{"YYYY.WW", "2024.10_feat", &Version{Major: 2024, Minor: 10, Type: TypeIntermediate, Intermediate: "_feat", Original: "2024.10_feat"}, -1},
+		{"YYYY.WW", "2024.53", nil, 5},
+		{"YYYY.WW", "2024.0", nil, 5},
+		{"YYYY.WW", "2024.10.3", nil, 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+"_"+test.input, func(t *testing.T) {
+			c, _ := ParseCalVer(test.format)
+			result, err := c.Parse(test.input)
+			if test.offset >= 0 {
+				parseErr, ok := err.(*ParseError)
+				if !ok {
+					t.Fatalf("Parse(%s) error = %v, want *ParseError", test.input, err)
+				}
+				if parseErr.Offset != test.offset {
+					t.Errorf("Parse(%s) offset = %d, want %d (%v)", test.input, parseErr.Offset, test.offset, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.input, err)
+			}
+			// Parse caches the identifier tokens the expected literal lacks
+			expected := *test.expected
+			expected.tokens = tokenize(&expected)
+			if *result != expected {
+				t.Errorf("Parse(%s) = %+v, want %+v", test.input, *result, expected)
+			}
+		})
+	}
+}
+
+func TestCalVerCurrentScheme(t *testing.T) {
+	useCalVer(t, "YY.0M.MICRO")
+
+	if !IsValid("24.05.1") || IsValid("1.2.3") {
+		t.Errorf("Parse does not use the current calendar versioning scheme")
+	}
+
+	sorted, err := Sort([]string{"24.10.0", "24.05.1", "23.12.4", "24.05.1~rc.1", "v24.05.0"})
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	expected := "23.12.4,v24.05.0,24.05.1~rc.1,24.05.1,24.10.0"
+	if strings.Join(sorted, ",") != expected {
+		t.Errorf("Sort() = %v, want %s", sorted, expected)
+	}
+}
+
+func TestCalVerBump(t *testing.T) {
+	may17 := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		input    string
+		bumpType BumpType
+		date     time.Time
+		expected string
+		wantErr  bool
+	}{
+		{"YY.0M.MICRO", "24.04.3", BumpCalendar, may17, "24.05.0", false},
+		{"YY.0M.MICRO", "24.05.3", BumpCalendar, may17, "24.05.4", false},
+		{"YY.0M.MICRO", "24.05.3", BumpSmart, may17, "24.05.4", false},
+		{"YY.0M.MICRO", "24.05.3~rc.1", BumpCalendar, may17, "24.05.3", false},
+		{"YY.0M.MICRO", "24.05.3~rc.1", BumpSmart, may17, "24.05.3~rc.2", false},
+		{"YY.0M.MICRO", "24.05.3", BumpRc, may17, "24.05.3~rc.1", false},
+		{"YY.0M.MICRO", "24.05.3.fix.1", BumpCalendar, may17, "24.05.4", false},
+		{"YY.0M.MICRO", "24.06.0", BumpCalendar, may17, "", true},
+		{"YY.0M.MICRO", "24.05.3", BumpPatch, may17, "", true},
+		{"YYYY.MM.DD", "2024.5.16", BumpCalendar, may17, "2024.5.17", false},
+		{"YYYY.MM.DD", "2024.5.17", BumpCalendar, may17, "", true},
+		{"YYYY.MM.DD", "2024.5.17~rc.1", BumpCalendar, may17, "2024.5.17", false},
+		{"YYYY.WW", "2024.19", BumpCalendar, may17, "2024.20", false},
+		{"YYYY.WW", "2024.52", BumpCalendar, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "2025.1", false},
+		{"0Y.0W.MICRO", "24.20.0", BumpCalendar, may17, "24.20.1", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+"_"+test.input+"_"+test.bumpType.String(), func(t *testing.T) {
+			useCalVer(t, test.format)
+			result, err := BumpWithOptions(test.input, test.bumpType, BumpOptions{Date: test.date})
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %s", result.BumpedVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump failed: %v", err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("Bump(%s, %s) = %s, want %s", test.input, test.bumpType, result.BumpedVersion, test.expected)
+			}
+		})
+	}
+
+	if _, err := Bump("1.2.3", BumpCalendar); err == nil {
+		t.Errorf("Expected error for calendar bump without a calendar versioning scheme")
+	}
+}
+
+func TestCalVerDate(t *testing.T) {
+	useCalVer(t, "YYYY.MM.DD")
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1715904000") // 2024-05-17 00:00:00 UTC
+	result, err := Bump("2024.1.1", BumpCalendar)
+	if err != nil {
+		t.Fatalf("Bump failed: %v", err)
+	}
+	if result.BumpedVersion != "2024.5.17" {
+		t.Errorf("Bump with SOURCE_DATE_EPOCH = %s, want 2024.5.17", result.BumpedVersion)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, err := CalVerDate(); err == nil {
+		t.Errorf("Expected error for invalid SOURCE_DATE_EPOCH")
+	}
+}
+
+func TestProjectConfigCalVer(t *testing.T) {
+	tests := []struct {
+		section string
+		format  string
+		wantErr bool
+	}{
+		{"version:\n  calver: YY.0M.MICRO\n", "YY.0M.MICRO", false},
+		{"version:\n  calver: YYYY.DD\n", "", true},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\n" + tt.section
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("GetProjectConfigFromFile failed: %v", err)
+			}
+			c, err := config.CalVer()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CalVer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (c == nil) != (tt.format == "") {
+				t.Fatalf("CalVer() = %v, want %s", c, tt.format)
+			}
+			if c != nil && c.String() != tt.format {
+				t.Errorf("CalVer() = %s, want %s", c, tt.format)
+			}
+		})
+	}
+}
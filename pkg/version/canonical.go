@@ -0,0 +1,99 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Equal reports whether two versions have the same precedence, i.e. Compare
+// returns 0. The 'v' prefix, the '-' or '~' prerelease delimiter, leading
+// zeros of numeric identifiers, trailing zero segments of N-part versions and
+// build metadata do not make versions different.
+func Equal(a, b *Version) bool {
+	return Compare(a, b) == 0
+}
+
+// Canonical returns the normalized form of the version:
+//   - no 'v' prefix and no build metadata
+//   - '~' prerelease delimiter (1.2.3-rc.1 -> 1.2.3~rc.1)
+//   - numeric identifiers without leading zeros (1.2.3~rc.01 -> 1.2.3~rc.1)
+//   - trailing zero segments of N-part versions removed (1.2.3.0 -> 1.2.3)
+//   - the zero padding of the calendar versioning format of the version scheme (24.05.1)
+//
+// Two versions of the same dialect are Equal exactly when their canonical forms
+// are identical. Prerelease identifiers of DialectSemVer2 versions keep their
+// '-' delimiter, the SemVer 2.0.0 grammar has no other form.
+func (v *Version) Canonical() string {
+	var b strings.Builder
+	if calver := v.scheme.calver(); calver != nil {
+		b.WriteString(calver.core(v))
+	} else {
+		fmt.Fprintf(&b, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+		b.WriteString(canonicalExtra(v.Extra))
+	}
+
+	switch v.Type {
+	case TypePrerelease:
+		if v.Dialect == DialectSemVer2 {
+			b.WriteString(v.Prerelease)
+		} else {
+			b.WriteByte('~')
+			b.WriteString(canonicalIdentifier(v.Prerelease[1:]))
+		}
+	case TypePostrelease:
+		b.WriteString(v.Postrelease[:1])
+		b.WriteString(canonicalIdentifier(v.Postrelease[1:]))
+	case TypeIntermediate:
+		b.WriteString(v.Intermediate[:1])
+		b.WriteString(canonicalIdentifier(v.Intermediate[1:]))
+	}
+	return b.String()
+}
+
+// canonicalExtra removes leading zeros and trailing zero segments from the
+// extra segments of an N-part version (.04.0 -> .4)
+func canonicalExtra(extra string) string {
+	var segments []string
+	var part string
+	for i := 0; i < len(extra); {
+		part, i = nextSegment(extra, i)
+		segments = append(segments, trimLeadingZeros(part))
+	}
+	for len(segments) > 0 && segments[len(segments)-1] == "0" {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) == 0 {
+		return ""
+	}
+	return "." + strings.Join(segments, ".")
+}
+
+// canonicalIdentifier removes leading zeros from the numeric parts of an
+// identifier and keeps its delimiters (rc.01_x -> rc.1_x)
+func canonicalIdentifier(identifier string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i <= len(identifier); i++ {
+		if i < len(identifier) && identifier[i] != '.' && identifier[i] != '_' {
+			continue
+		}
+		part := identifier[start:i]
+		if isNumericIdentifier(part) {
+			part = trimLeadingZeros(part)
+		}
+		b.WriteString(part)
+		if i < len(identifier) {
+			b.WriteByte(identifier[i])
+		}
+		start = i + 1
+	}
+	return b.String()
+}
+
+// trimLeadingZeros removes the leading zeros of a number, keeping a single 0
+func trimLeadingZeros(digits string) string {
+	if trimmed := strings.TrimLeft(digits, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
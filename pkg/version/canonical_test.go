@@ -0,0 +1,108 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"01.02.03", "1.2.3"},
+		{"v1.2.3-rc.1", "1.2.3~rc.1"},
+		{"1.2.3~rc.01", "1.2.3~rc.1"},
+		{"1.2.3~alpha.0_x.007", "1.2.3~alpha.0_x.7"},
+		{"1.2.3.fix.02", "1.2.3.fix.2"},
+		{"1.2.3_feat.01", "1.2.3_feat.1"},
+		{"1.2.3+build.5", "1.2.3"},
+		{"v1.2.3-rc.1+build.5", "1.2.3~rc.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.input, err)
+			}
+			if result := v.Canonical(); result != test.expected {
+				t.Errorf("Canonical(%s) = %s, want %s", test.input, result, test.expected)
+			}
+			canonical, err := Parse(v.Canonical())
+			if err != nil || !Equal(v, canonical) {
+				t.Errorf("Canonical(%s) = %s is not a valid equal version: %v", test.input, v.Canonical(), err)
+			}
+		})
+	}
+}
+
+func TestCanonicalSchemes(t *testing.T) {
+	useSegments(t, 5)
+	for input, expected := range map[string]string{"1.2.3.0": "1.2.3", "1.2.3.04.0": "1.2.3.4", "1.2.3.0.1": "1.2.3.0.1"} {
+		v, _ := Parse(input)
+		if result := v.Canonical(); result != expected {
+			t.Errorf("Canonical(%s) = %s, want %s", input, result, expected)
+		}
+	}
+
+	useCalVer(t, "YY.0M.MICRO")
+	v, _ := Parse("v24.05.1-rc.1")
+	if result := v.Canonical(); result != "24.05.1~rc.1" {
+		t.Errorf("Canonical(%s) = %s, want 24.05.1~rc.1", v, result)
+	}
+
+	s, err := ParseWithOptions("1.2.3-rc.1+build", DialectSemVer2)
+	if err != nil {
+		t.Fatalf("ParseWithOptions failed: %v", err)
+	}
+	SetCalVer(nil)
+	if result := s.Canonical(); result != "1.2.3-rc.1" {
+		t.Errorf("Canonical(%s) = %s, want 1.2.3-rc.1", s, result)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"v1.2.3", "1.2.3", true},
+		{"v1.2.3-rc.1", "1.2.3~rc.1", true},
+		{"1.2.3~rc.01", "1.2.3~rc.1", true},
+		{"1.2.3+build.1", "1.2.3+build.2", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2.3~rc.1", "1.2.3", false},
+		{"1.2.3~rc.1", "1.2.3~rc.1.0", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_"+test.b, func(t *testing.T) {
+			a, _ := Parse(test.a)
+			b, _ := Parse(test.b)
+			if result := Equal(a, b); result != test.expected {
+				t.Errorf("Equal(%s, %s) = %v, want %v", test.a, test.b, result, test.expected)
+			}
+			if canonical := a.Canonical() == b.Canonical(); canonical != test.expected {
+				t.Errorf("Canonical(%s) = %s, Canonical(%s) = %s, equal %v", test.a, a.Canonical(), test.b, b.Canonical(), test.expected)
+			}
+		})
+	}
+}
+
+func TestSortUnique(t *testing.T) {
+	result, err := Sort([]string{"1.2.4", "v1.2.3-rc.1", "1.2.3", "v1.2.3", "1.2.3~rc.01"})
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	expected := []string{"v1.2.3~rc.1", "1.2.3", "1.2.4"}
+	if len(result) != len(expected) {
+		t.Fatalf("Sort() = %v, want %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Sort()[%d] = %s, want %s", i, result[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Commit is a git commit with its abbreviated hash and full message
+type Commit struct {
+	Hash    string
+	Message string
+}
+
+// Subject returns the first line of the commit message
+func (c Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// conventionalSubject matches a Conventional Commits subject: type(scope)!: description
+var conventionalSubject = regexp.MustCompile(`^([A-Za-z]+)(\([^()]*\))?(!)?: \S`)
+
+// breakingFooter matches a breaking change footer of a Conventional Commits message
+var breakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: \S`)
+
+// ConventionalBump returns the bump a Conventional Commits message
+// (https://www.conventionalcommits.org) asks for: BumpMajor for a breaking change
+// ('!' before the colon or a BREAKING CHANGE footer), BumpMinor for feat,
+// BumpPatch for fix and BumpNone for other types and other messages
+func ConventionalBump(message string) BumpType {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	matches := conventionalSubject.FindStringSubmatch(subject)
+	if matches == nil {
+		return BumpNone
+	}
+	if matches[3] == "!" || breakingFooter.MatchString(body) {
+		return BumpMajor
+	}
+	switch strings.ToLower(matches[1]) {
+	case "feat":
+		return BumpMinor
+	case "fix":
+		return BumpPatch
+	}
+	return BumpNone
+}
+
+// BumpFromCommits bumps a version by the greatest bump its commits ask for by
+// Conventional Commits: major for breaking changes, else minor for features,
+// else patch. Commits without feat, fix or breaking changes give a patch bump.
+// The applied rule lists the commits that decided the bump. The version is
+// bumped as by BumpWithOptions, calendar versions are rejected.
+func BumpFromCommits(versionStr string, commits []Commit, opts BumpOptions) (*BumpResult, error) {
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits since version %s", versionStr)
+	}
+
+	bumpType, deciding := BumpNone, []string(nil)
+	for _, commit := range commits {
+		commitBump := ConventionalBump(commit.Message)
+		switch {
+		case commitBump == BumpNone:
+			continue
+		case bumpType == BumpNone || commitBump < bumpType:
+			// BumpMajor < BumpMinor < BumpPatch, the lower value is the greater bump
+			bumpType, deciding = commitBump, nil
+		case commitBump > bumpType:
+			continue
+		}
+		deciding = append(deciding, commit.Hash+" "+commit.Subject())
+	}
+
+	reason := fmt.Sprintf("no feat, fix or breaking change in %d commits", len(commits))
+	if bumpType == BumpNone {
+		bumpType = BumpPatch
+	} else {
+		reason = fmt.Sprintf("%s by %s", conventionalReason(bumpType), strings.Join(deciding, "; "))
+	}
+
+	result, err := bumpSteps(versionStr, opts, bumpType)
+	if err != nil {
+		return nil, err
+	}
+	result.AppliedRule += " (" + reason + ")"
+	return result, nil
+}
+
+// conventionalReason names the commits that ask for a bump type
+func conventionalReason(bumpType BumpType) string {
+	switch bumpType {
+	case BumpMajor:
+		return "breaking change"
+	case BumpMinor:
+		return "feat"
+	default:
+		return "fix"
+	}
+}
+
+// parseCommitLog parses git log output with the format %h%x1f%B%x1e into commits
+func parseCommitLog(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		hash, message, ok := strings.Cut(strings.TrimSpace(record), "\x1f")
+		if !ok {
+			continue
+		}
+		commits = append(commits, Commit{Hash: hash, Message: strings.TrimSpace(message)})
+	}
+	return commits
+}
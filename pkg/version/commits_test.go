@@ -0,0 +1,95 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConventionalBump(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected BumpType
+	}{
+		{"feat: add extract command", BumpMinor},
+		{"feat(api): add modules", BumpMinor},
+		{"Feat: capitalized type", BumpMinor},
+		{"fix: handle empty tags", BumpPatch},
+		{"fix(parser)!: reject leading zeros", BumpMajor},
+		{"feat!: drop Go 1.21", BumpMajor},
+		{"refactor: split parser\n\nBREAKING CHANGE: Parse no longer trims spaces", BumpMajor},
+		{"fix: typo\n\nBREAKING-CHANGE: renamed flag", BumpMajor},
+		{"docs: mention BREAKING CHANGE: in the guide", BumpNone},
+		{"chore: update dependencies", BumpNone},
+		{"Merge branch 'feature'", BumpNone},
+		{"feat:missing space", BumpNone},
+		{"feature: not a conventional type", BumpNone},
+		{"", BumpNone},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			if result := ConventionalBump(test.message); result != test.expected {
+				t.Errorf("ConventionalBump(%q) = %s, want %s", test.message, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestBumpFromCommits(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		messages []string
+		expected string
+		rule     string // part of the applied rule
+	}{
+		{"fix", "1.2.3", []string{"chore: ci", "fix: crash"}, "1.2.4", "(fix by 0000001 fix: crash)"},
+		{"feat", "1.2.3", []string{"fix: crash", "feat(cli): extract", "feat: canonical"}, "1.3.0", "(feat by 0000001 feat(cli): extract; 0000002 feat: canonical)"},
+		{"breaking", "1.2.3", []string{"feat: extract", "refactor: api\n\nBREAKING CHANGE: renamed"}, "2.0.0", "(breaking change by 0000001 refactor: api)"},
+		{"no conventional commits", "1.2.3", []string{"update readme", "chore: ci"}, "1.2.4", "(no feat, fix or breaking change in 2 commits)"},
+		{"build metadata", "1.2.3+build.5", []string{"feat: x"}, "1.3.0+build.5", "carry build metadata +build.5 (feat by 0000000 feat: x)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var commits []Commit
+			for i, message := range test.messages {
+				commits = append(commits, Commit{Hash: "000000" + string(rune('0'+i)), Message: message})
+			}
+			result, err := BumpFromCommits(test.version, commits, BumpOptions{})
+			if err != nil {
+				t.Fatalf("BumpFromCommits failed: %v", err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("BumpFromCommits(%s) = %s, want %s", test.version, result.BumpedVersion, test.expected)
+			}
+			if !strings.Contains(result.AppliedRule, test.rule) {
+				t.Errorf("AppliedRule = %q, want it to contain %q", result.AppliedRule, test.rule)
+			}
+		})
+	}
+
+	if _, err := BumpFromCommits("1.2.3", nil, BumpOptions{}); err == nil {
+		t.Errorf("Expected error without commits")
+	}
+	if _, err := Bump("1.2.3", BumpAuto); err == nil {
+		t.Errorf("Expected error for an auto bump without commits")
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	output := "abc1234\x1ffeat: add x\n\nbody line\n\x1e\ndef5678\x1ffix: y\n\x1e"
+	commits := parseCommitLog(output)
+	if len(commits) != 2 {
+		t.Fatalf("parseCommitLog() = %v, want 2 commits", commits)
+	}
+	if commits[0].Hash != "abc1234" || commits[0].Message != "feat: add x\n\nbody line" || commits[0].Subject() != "feat: add x" {
+		t.Errorf("commits[0] = %+v", commits[0])
+	}
+	if commits[1].Hash != "def5678" || commits[1].Subject() != "fix: y" {
+		t.Errorf("commits[1] = %+v", commits[1])
+	}
+	if commits := parseCommitLog(""); len(commits) != 0 {
+		t.Errorf("parseCommitLog(\"\") = %v, want no commits", commits)
+	}
+}
@@ -18,6 +18,205 @@ type ProjectConfig struct {
 		Name    string   `yaml:"name"`
 		Modules []string `yaml:"modules"`
 	} `yaml:"project"`
+	Version struct {
+		CalVer     string           `yaml:"calver"`   // calendar versioning format, e.g. YY.0M.MICRO
+		Segments   int              `yaml:"segments"` // numeric segments of N-part versions, e.g. 4 for 1.2.3.4
+		Labels     []LabelConfig    `yaml:"labels"`
+		Precedence PrecedenceConfig `yaml:"precedence"`
+		Bump       BumpConfig       `yaml:"bump"`
+		Release    ReleaseConfig    `yaml:"release"`
+	} `yaml:"version"`
+	Modules []Module `yaml:"-"` // project.modules entries with their tag prefixes
+}
+
+// LabelConfig declares a custom prerelease or postrelease label in .project.yml
+type LabelConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // prerelease or postrelease
+	Rank int    `yaml:"rank"` // order among labels of the same type
+}
+
+// PrecedenceConfig overrides the label order in .project.yml, each list
+// names every label of its type in ascending order
+type PrecedenceConfig struct {
+	Prerelease  []string `yaml:"prerelease"`
+	Postrelease []string `yaml:"postrelease"`
+}
+
+// BumpConfig declares the bump policy in .project.yml
+type BumpConfig struct {
+	Smart       map[string]string `yaml:"smart"`       // bump spec of smart bumps per version type, e.g. release: minor
+	Prerelease  string            `yaml:"prerelease"`  // default prerelease label, alpha when empty
+	Postrelease *bool             `yaml:"postrelease"` // allow bumps to postreleases, true when unset
+	Start       *int              `yaml:"start"`       // number of new identifiers, 1 when unset
+}
+
+// ReleaseConfig declares the files the release command updates in .project.yml
+type ReleaseConfig struct {
+	VersionFile string        `yaml:"version_file"` // written with the release tag, VERSION when empty
+	Files       []ReleaseFile `yaml:"files"`        // files whose occurrences of the current version are replaced
+	Message     string        `yaml:"message"`      // commit and tag message, {version} is replaced by the release tag
+}
+
+// ReleaseFile is a file updated by the release command. Without a pattern
+// every version equal to the current one is replaced, with a pattern such as
+// `"version": "{version}"` only the version at {version} is.
+type ReleaseFile struct {
+	Path    string `yaml:"path"`
+	Pattern string `yaml:"pattern"` // text around the version, {version} marks the version
+}
+
+// UnmarshalYAML decodes a release file that is either a path or a mapping
+// with a path and a pattern:
+//
+//	files:
+//	  - CMakeLists.txt
+//	  - path: package.json
+//	    pattern: '"version": "{version}"'
+func (f *ReleaseFile) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return node.Decode(&f.Path)
+	}
+
+	// The plain type has no UnmarshalYAML method and decodes the fields as usual
+	type plain ReleaseFile
+	if err := node.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	if f.Path == "" {
+		return fmt.Errorf("release file without a path")
+	}
+	if f.Pattern != "" {
+		before, _, found := strings.Cut(f.Pattern, "{version}")
+		switch {
+		case !found || strings.Count(f.Pattern, "{version}") > 1:
+			return fmt.Errorf("release file %s: pattern '%s' must contain {version} once", f.Path, f.Pattern)
+		case before == "":
+			return fmt.Errorf("release file %s: pattern '%s' must have text before {version}", f.Path, f.Pattern)
+		}
+	}
+	return nil
+}
+
+// LabelRegistry returns the default label registry extended with the labels
+// declared in the version.labels section of the configuration and ordered
+// by the version.precedence section
+func (c *ProjectConfig) LabelRegistry() (*LabelRegistry, error) {
+	labels := make([]Label, 0, len(c.Version.Labels))
+	for i, lc := range c.Version.Labels {
+		labelType, err := ParseType(lc.Type)
+		if err != nil {
+			return nil, fmt.Errorf("label %d (%s): %v", i+1, lc.Name, err)
+		}
+		labels = append(labels, Label{Name: lc.Name, Type: labelType, Rank: lc.Rank})
+	}
+	r, err := DefaultLabelRegistry().With(labels...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Version.Precedence.Prerelease) > 0 {
+		if r, err = r.WithPrecedence(TypePrerelease, c.Version.Precedence.Prerelease...); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.Version.Precedence.Postrelease) > 0 {
+		if r, err = r.WithPrecedence(TypePostrelease, c.Version.Precedence.Postrelease...); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// CalVer returns the calendar versioning scheme declared by version.calver,
+// nil when the project uses major.minor.patch versions
+func (c *ProjectConfig) CalVer() (*CalVer, error) {
+	if c.Version.CalVer == "" {
+		return nil, nil
+	}
+	return ParseCalVer(c.Version.CalVer)
+}
+
+// Segments returns the number of numeric version segments declared by
+// version.segments, 0 when the project uses major.minor.patch versions
+func (c *ProjectConfig) Segments() (int, error) {
+	n := c.Version.Segments
+	switch {
+	case n == 0:
+		return 0, nil
+	case n < 3 || n > MaxSegments:
+		return 0, fmt.Errorf("invalid number of version segments %d: must be 3 to %d", n, MaxSegments)
+	case n > 3 && c.Version.CalVer != "":
+		return 0, fmt.Errorf("version segments %d cannot be combined with calver format %s", n, c.Version.CalVer)
+	}
+	return n, nil
+}
+
+// BumpPolicy returns the default bump policy with the settings of the
+// version.bump section of the configuration. Bump specs and the prerelease label
+// are checked against the current label registry, custom labels of the
+// configuration require its label registry to be set (SetLabelRegistry), or
+// the policy to be taken from Scheme.
+func (c *ProjectConfig) BumpPolicy() (*BumpPolicy, error) {
+	return c.bumpPolicy(CurrentLabelRegistry())
+}
+
+// bumpPolicy returns the bump policy of the configuration checked against the
+// labels of a registry
+func (c *ProjectConfig) bumpPolicy(labels *LabelRegistry) (*BumpPolicy, error) {
+	policy := DefaultBumpPolicy()
+	bump := c.Version.Bump
+	if len(bump.Smart) > 0 {
+		policy.Smart = make(map[Type]BumpSpec, len(bump.Smart))
+	}
+	for typeStr, specStr := range bump.Smart {
+		t, err := ParseType(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("smart bump: %v", err)
+		}
+		spec, err := parseBumpSpec(specStr, labels)
+		if err != nil {
+			return nil, fmt.Errorf("smart bump of %s versions: %v", t, err)
+		}
+		policy.Smart[t] = spec
+	}
+	if bump.Prerelease != "" {
+		policy.Prerelease = strings.ToLower(bump.Prerelease)
+	}
+	if bump.Postrelease != nil {
+		policy.Postrelease = *bump.Postrelease
+	}
+	if bump.Start != nil {
+		policy.Start = *bump.Start
+	}
+
+	if err := policy.validate(labels); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Scheme returns the versioning scheme of the configuration, which parses,
+// compares and bumps versions of the project without changing the package
+// defaults
+func (c *ProjectConfig) Scheme() (*Scheme, error) {
+	labels, err := c.LabelRegistry()
+	if err != nil {
+		return nil, err
+	}
+	calver, err := c.CalVer()
+	if err != nil {
+		return nil, err
+	}
+	segments, err := c.Segments()
+	if err != nil {
+		return nil, err
+	}
+	policy, err := c.bumpPolicy(labels)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheme{Labels: labels, CalVer: calver, Segments: segments, Policy: policy}, nil
 }
 
 // ConfigProvider provides project configuration information
@@ -87,6 +286,15 @@ func (cp *ConfigProvider) GetAllModules() []string {
 	return cp.config.Project.Modules
 }
 
+// BumpPolicy returns the bump policy of the loaded configuration,
+// the default bump policy when no configuration is loaded
+func (cp *ConfigProvider) BumpPolicy() (*BumpPolicy, error) {
+	if cp.config == nil {
+		return DefaultBumpPolicy(), nil
+	}
+	return cp.config.BumpPolicy()
+}
+
 // HasConfig returns true if a valid configuration is loaded
 func (cp *ConfigProvider) HasConfig() bool {
 	return cp.config != nil
@@ -134,6 +342,9 @@ func (cp *ConfigProvider) validateConfig(config *ProjectConfig) error {
 			return fmt.Errorf("module %d cannot be empty", i+1)
 		}
 	}
+	if err := validateModules(config.Modules); err != nil {
+		return err
+	}
 
 	return nil
 }
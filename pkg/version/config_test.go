@@ -3,6 +3,7 @@ package version
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -257,4 +258,38 @@ func TestConfigProviderIntegration(t *testing.T) {
 			t.Errorf("GetAllModules()[%d] = %v, want %v", i, module, expectedModules[i])
 		}
 	}
-}
\ No newline at end of file
+}
+func TestProjectConfigReleaseFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    string
+		expected []ReleaseFile
+		wantErr  bool
+	}{
+		{"paths", "[VERSION.txt, CMakeLists.txt]", []ReleaseFile{{Path: "VERSION.txt"}, {Path: "CMakeLists.txt"}}, false},
+		{"pattern", "\n      - CMakeLists.txt\n      - path: package.json\n        pattern: '\"version\": \"{version}\"'",
+			[]ReleaseFile{{Path: "CMakeLists.txt"}, {Path: "package.json", Pattern: `"version": "{version}"`}}, false},
+		{"no placeholder", "\n      - path: package.json\n        pattern: 'version'", nil, true},
+		{"two placeholders", "\n      - path: package.json\n        pattern: '{version} {version}'", nil, true},
+		{"no text before placeholder", "\n      - path: package.json\n        pattern: '{version}\"'", nil, true},
+		{"no path", "\n      - pattern: 'v{version}'", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\nversion:\n  release:\n    files: " + tt.files + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetProjectConfigFromFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(config.Version.Release.Files, tt.expected) {
+				t.Errorf("Release.Files = %+v, want %+v", config.Version.Release.Files, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a parsed version range expression such as ">=1.2.0 <2.0.0",
+// "^1.2.3", "~1.2", "1.2.x", "1.4.x type=postrelease" or "1.2.3 || 1.3.0".
+//
+// Whitespace separated terms must all match, "||" separates alternatives.
+// Terms are compared with Compare, partial versions (1, 1.2, 1.2.x) compare
+// only the specified core components so they match every version type.
+// An upper bound <X of a release X excludes the prereleases of X, so
+// ">=1.2.0 <2.0.0" does not match 2.0.0~rc.1.
+type Constraint struct {
+	sets     [][]comparator
+	original string
+}
+
+// constraintOperator is the operator of a single constraint term
+type constraintOperator int
+
+const (
+	opEqual constraintOperator = iota
+	opNotEqual
+	opGreater
+	opGreaterEqual
+	opLess
+	opLessEqual
+	opType
+	opNotType
+)
+
+// comparator is a single term of a constraint
+type comparator struct {
+	op      constraintOperator
+	version *Version // version to compare with, nil for type terms and wildcards
+	parts   int      // number of core components to compare, 0 compares the full version
+	types   []Type   // version types for type terms
+}
+
+// ParseConstraint parses a version constraint expression.
+//
+// Supported terms:
+//   - comparisons: =1.2.3, !=1.2.3, >1.2.3, >=1.2.3, <2.0.0, <=1.2.3~rc.1
+//   - exact pins: 1.2.3, 1.2.3~rc.1, v1.2.3-rc.1
+//   - x-ranges: *, 1.x, 1.2.x, 1.2 (any version type of that core)
+//   - caret ranges: ^1.2.3 (>=1.2.3, core <2.0.0), ^0.2.3 (core <0.3.0)
+//   - tilde ranges: ~1.2.3 (>=1.2.3, core <1.3.0), ~1.2 (1.2.x)
+//   - type terms: type=postrelease, type!=intermediate, type=release,postrelease
+func ParseConstraint(constraintStr string) (*Constraint, error) {
+	constraint := &Constraint{original: strings.TrimSpace(constraintStr)}
+	if constraint.original == "" {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	for _, alternative := range strings.Split(constraint.original, "||") {
+		terms := strings.Fields(alternative)
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("invalid constraint '%s': empty alternative", constraintStr)
+		}
+
+		var set []comparator
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// Allow whitespace between an operator and its version (e.g. ">= 1.2.0")
+			if isConstraintOperator(term) && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+
+			comparators, err := parseConstraintTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint '%s': %v", constraintStr, err)
+			}
+			set = append(set, comparators...)
+		}
+		constraint.sets = append(constraint.sets, set)
+	}
+
+	return constraint, nil
+}
+
+// Check reports whether a version satisfies the constraint
+func (c *Constraint) Check(v *Version) bool {
+	for _, set := range c.sets {
+		matched := true
+		for _, comp := range set {
+			if !comp.match(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the original constraint expression
+func (c *Constraint) String() string {
+	return c.original
+}
+
+// Satisfies reports whether a version string satisfies a constraint expression
+func Satisfies(versionStr, constraintStr string) (bool, error) {
+	v, err := Parse(versionStr)
+	if err != nil {
+		return false, err
+	}
+	constraint, err := ParseConstraint(constraintStr)
+	if err != nil {
+		return false, err
+	}
+	return constraint.Check(v), nil
+}
+
+// isConstraintOperator reports whether a term consists of an operator only
+func isConstraintOperator(term string) bool {
+	switch term {
+	case "=", "==", "!=", ">", ">=", "<", "<=", "^", "~":
+		return true
+	}
+	return false
+}
+
+// parseConstraintTerm parses a single constraint term into one or more comparators
+func parseConstraintTerm(term string) ([]comparator, error) {
+	if strings.HasPrefix(term, "type!=") {
+		types, err := parseConstraintTypes(term[len("type!="):])
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{op: opNotType, types: types}}, nil
+	}
+	if strings.HasPrefix(term, "type=") {
+		types, err := parseConstraintTypes(term[len("type="):])
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{op: opType, types: types}}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(term, "^"):
+		return parseCaretRange(term[1:])
+	case strings.HasPrefix(term, "~"):
+		return parseTildeRange(term[1:])
+	}
+
+	op := opEqual
+	for _, prefix := range []struct {
+		text string
+		op   constraintOperator
+	}{
+		{">=", opGreaterEqual},
+		{"<=", opLessEqual},
+		{"!=", opNotEqual},
+		{"==", opEqual},
+		{">", opGreater},
+		{"<", opLess},
+		{"=", opEqual},
+	} {
+		if strings.HasPrefix(term, prefix.text) {
+			op = prefix.op
+			term = term[len(prefix.text):]
+			break
+		}
+	}
+
+	v, parts, err := parseConstraintVersion(term)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		// Wildcard matches everything, except when negated or used as a bound
+		switch op {
+		case opEqual, opGreaterEqual, opLessEqual:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("wildcard '%s' cannot be used with this operator", term)
+		}
+	}
+	return []comparator{{op: op, version: v, parts: parts}}, nil
+}
+
+// parseCaretRange expands ^P into comparators allowing changes that do not modify
+// the left-most non-zero core component
+func parseCaretRange(term string) ([]comparator, error) {
+	v, parts, err := parseConstraintVersion(term)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	specified := parts
+	if specified == 0 {
+		specified = 3
+	}
+
+	var upper comparator
+	switch {
+	case v.Major != 0 || specified == 1:
+		upper = comparator{op: opLess, version: &Version{Major: v.Major + 1}, parts: 1}
+	case v.Minor != 0 || specified == 2:
+		upper = comparator{op: opLess, version: &Version{Major: v.Major, Minor: v.Minor + 1}, parts: 2}
+	default:
+		upper = comparator{op: opLess, version: &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}, parts: 3}
+	}
+
+	return []comparator{{op: opGreaterEqual, version: v, parts: parts}, upper}, nil
+}
+
+// parseTildeRange expands ~P into comparators allowing patch level changes
+// (or minor level changes when only the major version is specified)
+func parseTildeRange(term string) ([]comparator, error) {
+	v, parts, err := parseConstraintVersion(term)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	upper := comparator{op: opLess, version: &Version{Major: v.Major, Minor: v.Minor + 1}, parts: 2}
+	if parts == 1 {
+		upper = comparator{op: opLess, version: &Version{Major: v.Major + 1}, parts: 1}
+	}
+
+	return []comparator{{op: opGreaterEqual, version: v, parts: parts}, upper}, nil
+}
+
+// parseConstraintVersion parses a full or partial version used in a constraint.
+// It returns the number of specified core components for partial versions (1 or 2),
+// 0 for full versions, and a nil version for a bare wildcard.
+func parseConstraintVersion(term string) (*Version, int, error) {
+	if term == "" {
+		return nil, 0, fmt.Errorf("missing version")
+	}
+
+	if v, err := Parse(term); err == nil {
+		return v, 0, nil
+	}
+
+	fields := strings.Split(strings.TrimPrefix(term, "v"), ".")
+	if len(fields) > 3 {
+		return nil, 0, fmt.Errorf("invalid version '%s'", term)
+	}
+
+	var core [3]int
+	parts := 0
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			// Everything after a wildcard must be a wildcard as well
+			for _, rest := range fields[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return nil, 0, fmt.Errorf("invalid version '%s'", term)
+				}
+			}
+			break
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || !isNumericIdentifier(field) {
+			return nil, 0, fmt.Errorf("invalid version '%s'", term)
+		}
+		core[i] = n
+		parts++
+	}
+
+	switch parts {
+	case 0:
+		return nil, 0, nil
+	case 3:
+		// Three numeric fields that Parse rejected, e.g. a core that does not fit
+		// the calendar versioning scheme (2024.13.1 for YYYY.MM.DD)
+		return nil, 0, fmt.Errorf("invalid version '%s'", term)
+	}
+	return &Version{Major: core[0], Minor: core[1], Patch: core[2]}, parts, nil
+}
+
+// parseConstraintTypes parses a comma separated list of version type names
+func parseConstraintTypes(list string) ([]Type, error) {
+	var types []Type
+	for _, name := range strings.Split(list, ",") {
+		t, err := ParseType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// match reports whether a version matches a single comparator
+func (c comparator) match(v *Version) bool {
+	switch c.op {
+	case opType, opNotType:
+		found := false
+		for _, t := range c.types {
+			if v.Type == t {
+				found = true
+				break
+			}
+		}
+		return found == (c.op == opType)
+	}
+
+	var result int
+	if c.parts == 0 {
+		result = Compare(v, c.version)
+	} else {
+		result = compareCore(v, c.version, c.parts)
+	}
+
+	switch c.op {
+	case opEqual:
+		return result == 0
+	case opNotEqual:
+		return result != 0
+	case opGreater:
+		return result > 0
+	case opGreaterEqual:
+		return result >= 0
+	case opLess:
+		// As in npm, <2.0.0 excludes the prereleases of 2.0.0 (2.0.0~rc.1)
+		if c.parts == 0 && c.version.Type == TypeRelease && v.Type == TypePrerelease && sameCore(v, c.version) {
+			return false
+		}
+		return result < 0
+	case opLessEqual:
+		return result <= 0
+	default:
+		return false
+	}
+}
+
+// sameCore reports whether two versions have the same numeric core, extra segments included
+func sameCore(a, b *Version) bool {
+	return a.Major == b.Major && a.Minor == b.Minor && a.Patch == b.Patch && compareExtra(a.Extra, b.Extra) == 0
+}
+
+// compareCore compares the first parts components of the major.minor.patch core
+func compareCore(a, b *Version, parts int) int {
+	if a.Major != b.Major || parts == 1 {
+		return a.Major - b.Major
+	}
+	if a.Minor != b.Minor || parts == 2 {
+		return a.Minor - b.Minor
+	}
+	return a.Patch - b.Patch
+}
+
+// LatestOptions configures the selection of the latest matching version
+type LatestOptions struct {
+	ExcludeTypes []Type // version types to skip (e.g. TypePrerelease, TypeIntermediate)
+}
+
+// FindLatest returns the highest version under Compare among tags that satisfy
+// the constraint and are not of an excluded type. Tags that are not valid
+// versions are skipped. The matching tag is returned as given. A nil
+// constraint matches every version.
+func FindLatest(tags []string, constraint *Constraint, opts LatestOptions) (string, error) {
+	var latestTag string
+	var latest *Version
+
+	for _, tag := range tags {
+		v, err := Parse(tag)
+		if err != nil {
+			continue
+		}
+		if isExcludedType(v.Type, opts.ExcludeTypes) {
+			continue
+		}
+		if constraint != nil && !constraint.Check(v) {
+			continue
+		}
+		if latest == nil || Compare(v, latest) > 0 {
+			latest = v
+			latestTag = tag
+		}
+	}
+
+	if latest == nil {
+		if constraint != nil {
+			return "", fmt.Errorf("no version tags match constraint '%s'", constraint)
+		}
+		return "", fmt.Errorf("no matching version tags found")
+	}
+	return latestTag, nil
+}
+
+// isExcludedType reports whether a version type is in the exclusion list
+func isExcludedType(t Type, excluded []Type) bool {
+	for _, e := range excluded {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
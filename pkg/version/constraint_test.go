@@ -0,0 +1,276 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		// Comparison operators
+		{">=1.2.0 <2.0.0", "1.2.0", true},
+		{">=1.2.0 <2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.9", false},
+		{">= 1.2.0 < 2.0.0", "1.5.0", true},
+		{">1.2.3", "1.2.3", false},
+		{">1.2.3", "1.2.3.fix.1", true},
+		{"<=1.2.3", "1.2.3", true},
+		{"<1.2.3", "1.2.3~rc.1", false},
+		{"<1.2.3", "1.2.2~rc.1", true},
+		{"<1.2.3~rc.2", "1.2.3~rc.1", true},
+		{">=1.2.0 <2.0.0", "2.0.0~rc.1", false},
+		{">=1.2.0 <2.0.0", "1.9.9~rc.1", true},
+		{"<=2.0.0", "2.0.0~rc.1", true},
+		{"!=1.2.3", "1.2.3", false},
+		{"!=1.2.3", "1.2.4", true},
+
+		// Exact pins
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "v1.2.3", true},
+		{"=1.2.3", "1.2.3+build.5", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2.3~rc.1", "1.2.3-rc.1", true},
+		{"==1.2.3~rc.1", "1.2.3~rc.2", false},
+
+		// Caret ranges
+		{"^1.2.3", "1.2.3", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.3~rc.1", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0~alpha.1", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3.fix.1", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.4", "1.4.0~rc.1", true},
+		{"^1.4", "1.9.0", true},
+		{"^1.4", "1.3.9", false},
+		{"^1", "1.0.0", true},
+		{"^1.2.3~rc.1", "1.2.3~rc.2", true},
+
+		// Tilde ranges
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.2.2", false},
+		{"~1.2", "1.2.0", true},
+		{"~1.2", "1.2.99_feat.1", true},
+		{"~1.2", "1.3.0~alpha.1", false},
+		{"~1", "1.9.0", true},
+		{"~ 1.2", "1.2.5", true},
+
+		// X-ranges
+		{"1.2.x", "1.2.0", true},
+		{"1.2.x", "1.2.7.fix.1", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.2", "1.2.3", true},
+		{"1.x", "1.99.0", true},
+		{"1.x.x", "2.0.0", false},
+		{"1.*", "1.4.0", true},
+		{"*", "0.0.1", true},
+		{"x", "99.0.0_feat", true},
+		{"v1.2.X", "1.2.1", true},
+		{">=1.2", "1.2.0~alpha", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<1.2", "1.2.0~alpha", false},
+		{"!=1.2", "1.2.5", false},
+		{"!=1.2", "1.3.0", true},
+
+		// Unions
+		{"1.2.3 || 1.3.0", "1.3.0", true},
+		{"1.2.3 || 1.3.0", "1.2.4", false},
+		{"<1.0.0 || >=2.0.0", "2.1.0", true},
+		{"<1.0.0 || >=2.0.0", "1.5.0", false},
+
+		// Version types
+		{"1.4.x type=postrelease", "1.4.2.fix.1", true},
+		{"1.4.x type=postrelease", "1.4.2", false},
+		{"1.4.x type=postrelease", "1.5.0.fix.1", false},
+		{">=1.2.0 type!=intermediate", "1.3.0_feat.1", false},
+		{">=1.2.0 type!=intermediate", "1.3.0.fix.1", true},
+		{"^1.2 type=release,postrelease", "1.3.0.post.2", true},
+		{"^1.2 type=release,postrelease", "1.3.0~rc.1", false},
+		{"type!=prerelease,intermediate", "1.3.0", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint+"_"+test.version, func(t *testing.T) {
+			c, err := ParseConstraint(test.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) failed: %v", test.constraint, err)
+			}
+			v, err := Parse(test.version)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.version, err)
+			}
+			if result := c.Check(v); result != test.expected {
+				t.Errorf("Constraint %q Check(%s) = %v, want %v", test.constraint, test.version, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		"1.2.3 ||",
+		"|| 1.2.3",
+		">=",
+		">=invalid",
+		"^1.2.3.4",
+		"1.x.2",
+		"1.2.3.x",
+		">*",
+		"!=*",
+		"type=unknown",
+		"type=",
+		"~foo",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			if _, err := ParseConstraint(test); err == nil {
+				t.Errorf("Expected error for constraint %q, but got none", test)
+			}
+		})
+	}
+}
+
+func TestParseConstraintCalVer(t *testing.T) {
+	useCalVer(t, "YYYY.MM.DD")
+
+	if c, err := ParseConstraint(">=2024.5.1"); err != nil || !c.Check(&Version{Major: 2024, Minor: 5, Patch: 17}) {
+		t.Errorf("ParseConstraint(>=2024.5.1) = %v, %v, want a constraint matching 2024.5.17", c, err)
+	}
+	// Full cores that are not calendar dates are not valid versions
+	for _, test := range []string{"2024.13.1", ">=24.5.1", "<2024.2.30"} {
+		if _, err := ParseConstraint(test); err == nil {
+			t.Errorf("Expected error for constraint %q with calver YYYY.MM.DD, but got none", test)
+		}
+	}
+}
+
+func TestConstraintString(t *testing.T) {
+	c, err := ParseConstraint("  >=1.2.0 <2.0.0 ")
+	if err != nil {
+		t.Fatalf("ParseConstraint failed: %v", err)
+	}
+	if c.String() != ">=1.2.0 <2.0.0" {
+		t.Errorf("String() = %q, want %q", c.String(), ">=1.2.0 <2.0.0")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	ok, err := Satisfies("1.4.3-rc.1", "^1.4")
+	if err != nil {
+		t.Fatalf("Satisfies failed: %v", err)
+	}
+	if !ok {
+		t.Errorf("Satisfies(1.4.3-rc.1, ^1.4) = false, want true")
+	}
+
+	if _, err := Satisfies("invalid", "^1.4"); err == nil {
+		t.Errorf("Expected error for invalid version")
+	}
+	if _, err := Satisfies("1.2.3", ">>1.2"); err == nil {
+		t.Errorf("Expected error for invalid constraint")
+	}
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Type
+		hasError bool
+	}{
+		{"release", TypeRelease, false},
+		{"prerelease", TypePrerelease, false},
+		{"Postrelease", TypePostrelease, false},
+		{"intermediate", TypeIntermediate, false},
+		{"invalid", TypeInvalid, true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseType(test.input)
+		if (err != nil) != test.hasError {
+			t.Errorf("ParseType(%q) error = %v, hasError %v", test.input, err, test.hasError)
+		}
+		if result != test.expected {
+			t.Errorf("ParseType(%q) = %v, want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestFindLatest(t *testing.T) {
+	tags := []string{
+		"v1.3.9",
+		"v1.4.0",
+		"v1.4.2",
+		"v1.4.3-rc.1",
+		"v1.4.2.fix.1",
+		"v1.4.2_feat.1",
+		"v1.5.0",
+		"v2.0.0-alpha.1",
+		"vnext",
+	}
+
+	tests := []struct {
+		constraint string
+		exclude    []Type
+		expected   string
+		hasError   bool
+	}{
+		{"*", nil, "v2.0.0-alpha.1", false},
+		{"*", []Type{TypePrerelease}, "v1.5.0", false},
+		{"1.4.x", nil, "v1.4.3-rc.1", false},
+		{"1.4.x", []Type{TypePrerelease}, "v1.4.2_feat.1", false},
+		{"1.4.x", []Type{TypePrerelease, TypeIntermediate}, "v1.4.2.fix.1", false},
+		{"1.4.x type=release", nil, "v1.4.2", false},
+		{"^1.4", []Type{TypePrerelease}, "v1.5.0", false},
+		{"<1.4", nil, "v1.3.9", false},
+		{"1.6.x", nil, "", true},
+		{"1.3.x", []Type{TypeRelease}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(test.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) failed: %v", test.constraint, err)
+			}
+			result, err := FindLatest(tags, c, LatestOptions{ExcludeTypes: test.exclude})
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Expected error for constraint %q, but got %s", test.constraint, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindLatest failed: %v", err)
+			}
+			if result != test.expected {
+				t.Errorf("FindLatest(%q, %v) = %s, want %s", test.constraint, test.exclude, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestFindLatestNilConstraint(t *testing.T) {
+	result, err := FindLatest([]string{"1.0.0", "1.2.0", "1.1.0"}, nil, LatestOptions{})
+	if err != nil {
+		t.Fatalf("FindLatest failed: %v", err)
+	}
+	if result != "1.2.0" {
+		t.Errorf("FindLatest = %s, want 1.2.0", result)
+	}
+
+	if _, err := FindLatest(nil, nil, LatestOptions{}); err == nil {
+		t.Errorf("Expected error for empty tag list")
+	}
+}
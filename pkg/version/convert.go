@@ -0,0 +1,352 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Target is a packaging ecosystem a version can be converted to
+type Target int
+
+const (
+	// TargetDeb is the Debian upstream version syntax compared by dpkg
+	TargetDeb Target = iota
+	// TargetRPM is the RPM Version tag syntax compared by rpmvercmp
+	TargetRPM
+	// TargetPEP440 is the Python package version syntax (PEP 440)
+	TargetPEP440
+	// TargetMaven is the Maven artifact version syntax (ComparableVersion)
+	TargetMaven
+	// TargetNuGet is the NuGet package version syntax (SemVer 2.0.0, case-insensitive labels)
+	TargetNuGet
+	// TargetNPM is the npm package version syntax (SemVer 2.0.0)
+	TargetNPM
+	// TargetWindows is the Windows FILEVERSION resource syntax (four 16-bit numbers)
+	TargetWindows
+)
+
+func (t Target) String() string {
+	switch t {
+	case TargetDeb:
+		return "deb"
+	case TargetRPM:
+		return "rpm"
+	case TargetPEP440:
+		return "pep440"
+	case TargetMaven:
+		return "maven"
+	case TargetNuGet:
+		return "nuget"
+	case TargetNPM:
+		return "npm"
+	case TargetWindows:
+		return "windows"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseTarget parses a conversion target name
+func ParseTarget(targetStr string) (Target, error) {
+	switch strings.ToLower(targetStr) {
+	case "deb", "debian":
+		return TargetDeb, nil
+	case "rpm":
+		return TargetRPM, nil
+	case "pep440", "python":
+		return TargetPEP440, nil
+	case "maven":
+		return TargetMaven, nil
+	case "nuget":
+		return TargetNuGet, nil
+	case "npm":
+		return TargetNPM, nil
+	case "windows", "fileversion":
+		return TargetWindows, nil
+	default:
+		return TargetDeb, fmt.Errorf("unknown target: %s (supported: deb, rpm, pep440, maven, nuget, npm, windows)", targetStr)
+	}
+}
+
+// Convert converts a version to the native syntax of a packaging ecosystem.
+// The result sorts in the target ecosystem exactly as the versions sort under
+// Compare. Versions that cannot be represented without changing their order
+// (e.g. intermediate versions in npm) are rejected with an error.
+//
+// Mappings:
+//   - deb: 1.2.3~rc.1, 1.2.3+fix.2, 1.2.3.feat.1
+//   - rpm: 1.2.3~rc^1, 1.2.3^fix^2, 1.2.3.feat^1 (numbers follow '^' so they sort before words)
+//   - pep440: 1.2.3rc1, 1.2.3a1, 1.2.3.post2 (only alpha, beta, rc and post labels with one number)
+//   - maven: 1.2.3-rc-1, 1.2.3-fix-2 (alpha, beta, rc, fix, next and post labels with one number)
+//   - nuget, npm: 1.2.3-rc.1 (prerelease versions only, nuget rejects uppercase identifiers)
+//   - windows: 1,2,3,4 (releases with up to four segments of at most 65535)
+//
+// The extra segments of N-part versions are kept by pep440 and windows only.
+// Build metadata is kept for nuget and npm and dropped for the other targets.
+func Convert(v *Version, target Target) (string, error) {
+	core := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	switch {
+	case target == TargetPEP440:
+		core += v.Extra
+	case target != TargetWindows && v.Extra != "":
+		return "", convertError(v, target, "versions with more than 3 numeric segments have no equivalent")
+	}
+
+	switch target {
+	case TargetDeb:
+		return convertDeb(v, core)
+	case TargetRPM:
+		return convertRPM(v, core)
+	case TargetPEP440:
+		return convertPEP440(v, core)
+	case TargetMaven:
+		return convertMaven(v, core)
+	case TargetNuGet, TargetNPM:
+		return convertSemVer(v, core, target)
+	case TargetWindows:
+		return convertWindows(v)
+	default:
+		return "", fmt.Errorf("unknown target: %v", target)
+	}
+}
+
+// convertDeb converts a version to a Debian upstream version. dpkg sorts '~'
+// before the end of the string and '.' after '+', which gives the order
+// prerelease < release < postrelease < intermediate.
+func convertDeb(v *Version, core string) (string, error) {
+	tokens, err := convertTokens(v, TargetDeb)
+	if err != nil {
+		return "", err
+	}
+
+	switch v.Type {
+	case TypePrerelease:
+		return core + "~" + strings.Join(tokens, "."), nil
+	case TypePostrelease:
+		return core + "+" + strings.Join(tokens, "."), nil
+	case TypeIntermediate:
+		return core + "." + strings.Join(tokens, "."), nil
+	default:
+		return core, nil
+	}
+}
+
+// convertRPM converts a version to an RPM Version tag. rpmvercmp sorts numeric
+// segments after alphabetic ones, so numeric identifiers are introduced by '^'
+// which sorts after the end of the string but before any other segment.
+func convertRPM(v *Version, core string) (string, error) {
+	tokens, err := convertTokens(v, TargetRPM)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(core)
+	switch v.Type {
+	case TypePrerelease:
+		b.WriteString("~")
+	case TypePostrelease:
+		b.WriteString("^")
+	case TypeIntermediate:
+		b.WriteString(".")
+	}
+	for i, token := range tokens {
+		switch {
+		case isNumericIdentifier(token):
+			b.WriteString("^")
+		case i > 0:
+			b.WriteString(".")
+		}
+		b.WriteString(token)
+	}
+	return b.String(), nil
+}
+
+// convertPEP440 converts a version to a normalized PEP 440 version
+func convertPEP440(v *Version, core string) (string, error) {
+	switch v.Type {
+	case TypePrerelease:
+		if !v.scheme.labels().ordered("alpha", "beta", "rc") {
+			return "", convertError(v, TargetPEP440, "prerelease label precedence differs from alpha < beta < rc")
+		}
+		label, number, err := convertLabelNumber(v, TargetPEP440, map[string]string{"alpha": "a", "beta": "b", "rc": "rc"})
+		if err != nil {
+			return "", err
+		}
+		if number == "" {
+			number = "0"
+		}
+		return core + label + number, nil
+	case TypePostrelease:
+		label, number, err := convertLabelNumber(v, TargetPEP440, map[string]string{"post": ".post"})
+		if err != nil {
+			return "", err
+		}
+		if number == "" {
+			number = "0"
+		}
+		return core + label + number, nil
+	case TypeIntermediate:
+		return "", convertError(v, TargetPEP440, "intermediate versions have no equivalent")
+	default:
+		return core, nil
+	}
+}
+
+// convertMaven converts a version to a Maven version. Known qualifiers alpha,
+// beta and rc sort before the release, unknown qualifiers (fix, next, post)
+// sort after it in lexical order.
+func convertMaven(v *Version, core string) (string, error) {
+	var labels map[string]string
+	var order []string
+	switch v.Type {
+	case TypePrerelease:
+		labels = map[string]string{"alpha": "alpha", "beta": "beta", "rc": "rc"}
+		order = []string{"alpha", "beta", "rc"}
+	case TypePostrelease:
+		labels = map[string]string{"fix": "fix", "next": "next", "post": "post"}
+		order = []string{"fix", "next", "post"}
+	case TypeIntermediate:
+		return "", convertError(v, TargetMaven, "intermediate versions have no equivalent")
+	default:
+		return core, nil
+	}
+	if !v.scheme.labels().ordered(order...) {
+		return "", convertError(v, TargetMaven, fmt.Sprintf("%s label precedence differs from %s", v.Type, strings.Join(order, " < ")))
+	}
+
+	label, number, err := convertLabelNumber(v, TargetMaven, labels)
+	if err != nil {
+		return "", err
+	}
+	if number == "" {
+		return core + "-" + label, nil
+	}
+	return core + "-" + label + "-" + number, nil
+}
+
+// convertSemVer converts a version to a SemVer 2.0.0 version for npm and NuGet
+func convertSemVer(v *Version, core string, target Target) (string, error) {
+	switch v.Type {
+	case TypePostrelease, TypeIntermediate:
+		return "", convertError(v, target, fmt.Sprintf("%s versions have no equivalent", v.Type))
+	case TypeRelease:
+		return core + v.Build, nil
+	}
+
+	var prerelease string
+	if v.Dialect == DialectSemVer2 {
+		prerelease = strings.TrimPrefix(v.Prerelease, "-")
+	} else {
+		tokens, err := convertTokens(v, target)
+		if err != nil {
+			return "", err
+		}
+		prerelease = strings.Join(tokens, ".")
+	}
+
+	if target == TargetNuGet && strings.ToLower(prerelease) != prerelease {
+		return "", convertError(v, target, "nuget compares prerelease labels case-insensitively")
+	}
+	return core + "-" + prerelease + v.Build, nil
+}
+
+// convertWindows converts a release to a Windows FILEVERSION of four 16-bit
+// numbers, missing segments are 0 (1.2.3 -> 1,2,3,0)
+func convertWindows(v *Version) (string, error) {
+	if v.Type != TypeRelease {
+		return "", convertError(v, TargetWindows, fmt.Sprintf("%s versions have no equivalent", v.Type))
+	}
+	segments := v.Segments()
+	if len(segments) > 4 {
+		return "", convertError(v, TargetWindows, "at most 4 numeric segments are supported")
+	}
+
+	parts := make([]string, 4)
+	for i := range parts {
+		n := segmentAt(segments, i)
+		if n > 65535 {
+			return "", convertError(v, TargetWindows, fmt.Sprintf("segment %d exceeds 65535", n))
+		}
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// convertTokens returns the identifiers of the type specific part of a version
+// (e.g. "~rc.1_x" -> ["rc", "1", "x"]) with numeric identifiers normalized.
+// Identifiers that are not purely numeric or alphabetic are rejected.
+func convertTokens(v *Version, target Target) ([]string, error) {
+	var identifier string
+	switch v.Type {
+	case TypePrerelease:
+		identifier = v.Prerelease
+	case TypePostrelease:
+		identifier = v.Postrelease
+	case TypeIntermediate:
+		identifier = v.Intermediate
+	default:
+		return nil, nil
+	}
+
+	// deb, rpm, nuget and npm order labels lexically, custom label ranks must agree
+	if target != TargetPEP440 && target != TargetMaven && v.Type != TypeIntermediate && !v.scheme.labels().lexicalOrder(v.Type) {
+		return nil, convertError(v, target, fmt.Sprintf("%s label ranks differ from the lexical label order", v.Type))
+	}
+
+	// Every identifier starts with its one character delimiter (~, -, . or _)
+	tokens := splitIdentifier(identifier[1:])
+	for i, token := range tokens {
+		switch {
+		case isNumericIdentifier(token):
+			tokens[i] = strings.TrimLeft(token, "0")
+			if tokens[i] == "" {
+				tokens[i] = "0"
+			}
+		case !isAlphaToken(token):
+			return nil, convertError(v, target, fmt.Sprintf("identifier '%s' must be numeric or alphabetic", token))
+		}
+	}
+	return tokens, nil
+}
+
+// convertLabelNumber maps the label of a version through labels and returns it
+// together with its single optional numeric identifier (empty when missing)
+func convertLabelNumber(v *Version, target Target, labels map[string]string) (string, string, error) {
+	tokens, err := convertTokens(v, target)
+	if err != nil {
+		return "", "", err
+	}
+
+	label, ok := labels[tokens[0]]
+	if !ok {
+		return "", "", convertError(v, target, fmt.Sprintf("label '%s' has no equivalent", tokens[0]))
+	}
+	switch {
+	case len(tokens) == 1:
+		return label, "", nil
+	case len(tokens) == 2 && isNumericIdentifier(tokens[1]):
+		return label, tokens[1], nil
+	default:
+		return "", "", convertError(v, target, "only a label with a single numeric identifier is supported")
+	}
+}
+
+// convertError returns an error for a version that cannot be converted to a target
+func convertError(v *Version, target Target, reason string) error {
+	return fmt.Errorf("cannot convert version %s to %s: %s", v, target, reason)
+}
+
+// isAlphaToken reports whether an identifier consists of ASCII letters only
+func isAlphaToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlpha(s[i]) {
+			return false
+		}
+	}
+	return true
+}
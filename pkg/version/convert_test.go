@@ -0,0 +1,240 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Target
+		hasError bool
+	}{
+		{"deb", TargetDeb, false},
+		{"Debian", TargetDeb, false},
+		{"rpm", TargetRPM, false},
+		{"pep440", TargetPEP440, false},
+		{"python", TargetPEP440, false},
+		{"maven", TargetMaven, false},
+		{"nuget", TargetNuGet, false},
+		{"NPM", TargetNPM, false},
+		{"windows", TargetWindows, false},
+		{"fileversion", TargetWindows, false},
+		{"gem", TargetDeb, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseTarget(test.input)
+			if (err != nil) != test.hasError {
+				t.Fatalf("ParseTarget(%q) error = %v, hasError %v", test.input, err, test.hasError)
+			}
+			if result != test.expected {
+				t.Errorf("ParseTarget(%q) = %v, want %v", test.input, result, test.expected)
+			}
+			if !test.hasError && result.String() == "unknown" {
+				t.Errorf("Target(%d).String() = unknown", result)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		input    string
+		target   Target
+		expected string
+		hasError bool
+	}{
+		// Debian
+		{"1.2.3", TargetDeb, "1.2.3", false},
+		{"v1.2.3", TargetDeb, "1.2.3", false},
+		{"1.2.3~rc.1", TargetDeb, "1.2.3~rc.1", false},
+		{"1.2.3-alpha_x", TargetDeb, "1.2.3~alpha.x", false},
+		{"1.2.3~alpha.01", TargetDeb, "1.2.3~alpha.1", false},
+		{"1.2.3.fix.2", TargetDeb, "1.2.3+fix.2", false},
+		{"1.2.3_feat.1", TargetDeb, "1.2.3.feat.1", false},
+		{"1.2.3+build.5", TargetDeb, "1.2.3", false},
+
+		// RPM
+		{"1.2.3", TargetRPM, "1.2.3", false},
+		{"1.2.3~rc.1", TargetRPM, "1.2.3~rc^1", false},
+		{"1.2.3~rc", TargetRPM, "1.2.3~rc", false},
+		{"1.2.3~alpha_x", TargetRPM, "1.2.3~alpha.x", false},
+		{"1.2.3.fix.2", TargetRPM, "1.2.3^fix^2", false},
+		{"1.2.3_feat.1", TargetRPM, "1.2.3.feat^1", false},
+		{"1.2.3~rc.1+build.5", TargetRPM, "1.2.3~rc^1", false},
+
+		// PEP 440
+		{"1.2.3", TargetPEP440, "1.2.3", false},
+		{"1.2.3~alpha.1", TargetPEP440, "1.2.3a1", false},
+		{"1.2.3~beta.2", TargetPEP440, "1.2.3b2", false},
+		{"1.2.3~rc", TargetPEP440, "1.2.3rc0", false},
+		{"1.2.3~rc.10", TargetPEP440, "1.2.3rc10", false},
+		{"1.2.3.post.2", TargetPEP440, "1.2.3.post2", false},
+		{"1.2.3.post", TargetPEP440, "1.2.3.post0", false},
+		{"1.2.3~pre.1", TargetPEP440, "", true},
+		{"1.2.3~rc_x", TargetPEP440, "", true},
+		{"1.2.3.fix.1", TargetPEP440, "", true},
+		{"1.2.3_feat.1", TargetPEP440, "", true},
+
+		// Maven
+		{"1.2.3", TargetMaven, "1.2.3", false},
+		{"1.2.3~rc.1", TargetMaven, "1.2.3-rc-1", false},
+		{"1.2.3~alpha", TargetMaven, "1.2.3-alpha", false},
+		{"1.2.3.fix.2", TargetMaven, "1.2.3-fix-2", false},
+		{"1.2.3.next", TargetMaven, "1.2.3-next", false},
+		{"1.2.3~pre.1", TargetMaven, "", true},
+		{"1.2.3.fix_x", TargetMaven, "", true},
+		{"1.2.3_feat.1", TargetMaven, "", true},
+
+		// NuGet
+		{"1.2.3", TargetNuGet, "1.2.3", false},
+		{"1.2.3~rc.1", TargetNuGet, "1.2.3-rc.1", false},
+		{"1.2.3~rc.1+build.5", TargetNuGet, "1.2.3-rc.1+build.5", false},
+		{"1.2.3~rc_X", TargetNuGet, "", true},
+		{"1.2.3.fix.1", TargetNuGet, "", true},
+
+		// npm
+		{"1.2.3", TargetNPM, "1.2.3", false},
+		{"1.2.3+git.abc", TargetNPM, "1.2.3+git.abc", false},
+		{"1.2.3~alpha_x", TargetNPM, "1.2.3-alpha.x", false},
+		{"1.2.3~rc_X", TargetNPM, "1.2.3-rc.X", false},
+		{"1.2.3~alpha.007", TargetNPM, "1.2.3-alpha.7", false},
+		{"1.2.3.fix.1", TargetNPM, "", true},
+		{"1.2.3_feat.1", TargetNPM, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target.String()+"_"+test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.input, err)
+			}
+			result, err := Convert(v, test.target)
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Expected error converting %s to %s, got %s", test.input, test.target, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert(%s, %s) failed: %v", test.input, test.target, err)
+			}
+			if result != test.expected {
+				t.Errorf("Convert(%s, %s) = %s, want %s", test.input, test.target, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestConvertSemVer2Dialect(t *testing.T) {
+	v, err := ParseWithOptions("1.0.0-DEV-SNAPSHOT.1+build", DialectSemVer2)
+	if err != nil {
+		t.Fatalf("ParseWithOptions failed: %v", err)
+	}
+
+	result, err := Convert(v, TargetNPM)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if result != "1.0.0-DEV-SNAPSHOT.1+build" {
+		t.Errorf("Convert(npm) = %s, want 1.0.0-DEV-SNAPSHOT.1+build", result)
+	}
+
+	if _, err := Convert(v, TargetDeb); err == nil {
+		t.Errorf("Expected error converting identifier with '-' to deb")
+	}
+}
+
+// convertOrderCorpus is a list of versions in ascending Compare order
+var convertOrderCorpus = []string{
+	"1.2.2",
+	"1.2.3~alpha",
+	"1.2.3~alpha.1",
+	"1.2.3~alpha.2",
+	"1.2.3~alpha.10",
+	"1.2.3~alpha.10.1",
+	"1.2.3~alpha_x",
+	"1.2.3~beta",
+	"1.2.3~beta.1",
+	"1.2.3~pre.1",
+	"1.2.3~rc.1",
+	"1.2.3~rc.2",
+	"1.2.3",
+	"1.2.3.fix",
+	"1.2.3.fix.1",
+	"1.2.3.fix.2",
+	"1.2.3.next.1",
+	"1.2.3.post.1",
+	"1.2.3.post.11",
+	"1.2.3_feat",
+	"1.2.3_feat.1",
+	"1.2.3_feat.2",
+	"1.2.3_feat_x",
+	"1.2.3_fix.1",
+	"1.2.4~alpha.1",
+	"1.2.4",
+	"1.10.0",
+}
+
+// assertConvertOrder converts the corpus to a target and checks that the
+// converted versions sort in the same order under the target comparison
+func assertConvertOrder(t *testing.T, target Target, compare func(a, b string) (int, error)) {
+	t.Helper()
+
+	sorted, err := Sort(convertOrderCorpus)
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	for i := range sorted {
+		if sorted[i] != convertOrderCorpus[i] {
+			t.Fatalf("Corpus is not in Compare order at %d: %s", i, convertOrderCorpus[i])
+		}
+	}
+
+	var converted []string
+	for _, input := range convertOrderCorpus {
+		v, _ := Parse(input)
+		result, err := Convert(v, target)
+		if err != nil {
+			// Not representable in this target
+			continue
+		}
+		converted = append(converted, result)
+	}
+
+	for i := 0; i < len(converted); i++ {
+		for j := i + 1; j < len(converted); j++ {
+			result, err := compare(converted[i], converted[j])
+			if err != nil {
+				t.Fatalf("Compare %s %s failed: %v", converted[i], converted[j], err)
+			}
+			if result >= 0 {
+				t.Errorf("%s: %s should sort before %s", target, converted[i], converted[j])
+			}
+		}
+	}
+}
+
+func TestConvertOrderNPM(t *testing.T) {
+	assertConvertOrder(t, TargetNPM, func(a, b string) (int, error) {
+		va, err := ParseWithOptions(a, DialectSemVer2)
+		if err != nil {
+			return 0, err
+		}
+		vb, err := ParseWithOptions(b, DialectSemVer2)
+		if err != nil {
+			return 0, err
+		}
+		return Compare(va, vb), nil
+	})
+}
+
+func TestConvertOrderDeb(t *testing.T) {
+	assertConvertOrder(t, TargetDeb, CompareDeb)
+}
+
+func TestConvertOrderRPM(t *testing.T) {
+	assertConvertOrder(t, TargetRPM, CompareRPM)
+}
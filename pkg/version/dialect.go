@@ -0,0 +1,169 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect selects the grammar used to parse version strings
+type Dialect int
+
+const (
+	// DialectExtended is the project grammar with prerelease (~), postrelease (.)
+	// and intermediate (_) identifiers. It is the default used by Parse.
+	DialectExtended Dialect = iota
+	// DialectSemVer2 is strict Semantic Versioning 2.0.0 (https://semver.org/spec/v2.0.0.html)
+	DialectSemVer2
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectExtended:
+		return "extended"
+	case DialectSemVer2:
+		return "semver2"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseDialect parses a dialect name
+func ParseDialect(dialectStr string) (Dialect, error) {
+	switch strings.ToLower(dialectStr) {
+	case "extended", "":
+		return DialectExtended, nil
+	case "semver2", "semver":
+		return DialectSemVer2, nil
+	default:
+		return DialectExtended, fmt.Errorf("unknown dialect: %s (supported: extended, semver2)", dialectStr)
+	}
+}
+
+// versionSemVer2 is the regular expression suggested by the SemVer 2.0.0 specification
+var versionSemVer2 = regexp.MustCompile(`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)` +
+	`(?:-((?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// ParseWithOptions parses a version string using the given dialect.
+// DialectExtended behaves exactly like Parse. DialectSemVer2 accepts only strict
+// SemVer 2.0.0 strings: no 'v' prefix, no leading zeros in numeric identifiers,
+// '-' prerelease and '+' build metadata. Git tag conversion is not applied.
+func ParseWithOptions(versionStr string, dialect Dialect) (*Version, error) {
+	switch dialect {
+	case DialectExtended:
+		return Parse(versionStr)
+	case DialectSemVer2:
+		return parseSemVer2(versionStr)
+	default:
+		return nil, fmt.Errorf("unknown dialect: %v", dialect)
+	}
+}
+
+// parseSemVer2 parses a strict SemVer 2.0.0 version string
+func parseSemVer2(versionStr string) (*Version, error) {
+	versionStr = strings.TrimSpace(versionStr)
+
+	matches := versionSemVer2.FindStringSubmatch(versionStr)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid semver2 version format: %s", versionStr)
+	}
+
+	var core [3]int
+	for i := range core {
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver2 version format: %s: numeric identifier %s is too large", versionStr, matches[i+1])
+		}
+		core[i] = n
+	}
+
+	version := &Version{
+		Major:    core[0],
+		Minor:    core[1],
+		Patch:    core[2],
+		Type:     TypeRelease,
+		Original: versionStr,
+		Dialect:  DialectSemVer2,
+	}
+	if matches[4] != "" {
+		version.Type = TypePrerelease
+		version.Prerelease = "-" + matches[4]
+	}
+	if matches[5] != "" {
+		version.Build = "+" + matches[5]
+	}
+	return version, nil
+}
+
+// compareSemVer2Identifiers compares prerelease identifiers using SemVer 2.0.0 precedence.
+// The leading delimiter ('-' or '~') is ignored, identifiers are separated by dots only.
+func compareSemVer2Identifiers(a, b string) int {
+	aParts := strings.Split(trimDelimiter(a), ".")
+	bParts := strings.Split(trimDelimiter(b), ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if result := compareSemVer2Part(aParts[i], bParts[i]); result != 0 {
+			return result
+		}
+	}
+
+	// A larger set of identifiers has higher precedence if all preceding ones are equal
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// trimDelimiter removes the leading '-' or '~' delimiter of a prerelease identifier
+func trimDelimiter(identifier string) string {
+	if identifier != "" && (identifier[0] == '-' || identifier[0] == '~') {
+		return identifier[1:]
+	}
+	return identifier
+}
+
+// compareSemVer2Part compares two SemVer 2.0.0 prerelease identifiers
+func compareSemVer2Part(a, b string) int {
+	aNumeric := isNumericIdentifier(a)
+	bNumeric := isNumericIdentifier(b)
+
+	switch {
+	case aNumeric && bNumeric:
+		// Numeric identifiers have no leading zeros, so a longer one is larger.
+		// Comparing by length first avoids overflow on arbitrarily large numbers.
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		// Numeric identifiers always have lower precedence than alphanumeric ones
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		// Alphanumeric identifiers are compared lexically in ASCII sort order
+		return strings.Compare(a, b)
+	}
+}
+
+// isNumericIdentifier reports whether an identifier consists of digits only
+func isNumericIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	for i := 0; i < len(identifier); i++ {
+		if identifier[i] < '0' || identifier[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
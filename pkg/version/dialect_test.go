@@ -0,0 +1,239 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestParseDialect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Dialect
+		hasError bool
+	}{
+		{"extended", DialectExtended, false},
+		{"", DialectExtended, false},
+		{"semver2", DialectSemVer2, false},
+		{"SemVer2", DialectSemVer2, false},
+		{"semver", DialectSemVer2, false},
+		{"pep440", DialectExtended, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseDialect(test.input)
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Expected error for dialect %q, but got none", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for dialect %q: %v", test.input, err)
+			}
+			if result != test.expected {
+				t.Errorf("ParseDialect(%q) = %v, want %v", test.input, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestDialectString(t *testing.T) {
+	if DialectExtended.String() != "extended" {
+		t.Errorf("DialectExtended.String() = %s, want extended", DialectExtended.String())
+	}
+	if DialectSemVer2.String() != "semver2" {
+		t.Errorf("DialectSemVer2.String() = %s, want semver2", DialectSemVer2.String())
+	}
+}
+
+func TestParseWithOptionsSemVer2(t *testing.T) {
+	tests := []struct {
+		input      string
+		typ        Type
+		prerelease string
+		hasError   bool
+	}{
+		// Valid versions from the specification
+		{"0.0.4", TypeRelease, "", false},
+		{"1.2.3", TypeRelease, "", false},
+		{"10.20.30", TypeRelease, "", false},
+		{"1.1.2-prerelease+meta", TypePrerelease, "-prerelease", false},
+		{"1.1.2+meta", TypeRelease, "", false},
+		{"1.1.2+meta-valid", TypeRelease, "", false},
+		{"1.0.0-alpha", TypePrerelease, "-alpha", false},
+		{"1.0.0-beta", TypePrerelease, "-beta", false},
+		{"1.0.0-alpha.beta", TypePrerelease, "-alpha.beta", false},
+		{"1.0.0-alpha.beta.1", TypePrerelease, "-alpha.beta.1", false},
+		{"1.0.0-alpha.1", TypePrerelease, "-alpha.1", false},
+		{"1.0.0-alpha0.valid", TypePrerelease, "-alpha0.valid", false},
+		{"1.0.0-alpha.0valid", TypePrerelease, "-alpha.0valid", false},
+		{"1.0.0-rc.1+build.1", TypePrerelease, "-rc.1", false},
+		{"1.2.3-beta", TypePrerelease, "-beta", false},
+		{"10.2.3-DEV-SNAPSHOT", TypePrerelease, "-DEV-SNAPSHOT", false},
+		{"1.2.3-SNAPSHOT-123", TypePrerelease, "-SNAPSHOT-123", false},
+		{"2.0.0+build.1848", TypeRelease, "", false},
+		{"1.0.0-0A.is.legal", TypePrerelease, "-0A.is.legal", false},
+		{"1.0.0+0.build.1-rc.10000aaa-kk-0.1", TypeRelease, "", false},
+		{"1.0.0--", TypePrerelease, "--", false},
+		{"1.2.3----RC-SNAPSHOT.12.9.1--.12+788", TypePrerelease, "----RC-SNAPSHOT.12.9.1--.12", false},
+
+		// Invalid versions from the specification
+		{"1", TypeInvalid, "", true},
+		{"1.2", TypeInvalid, "", true},
+		{"1.2.3-0123", TypeInvalid, "", true},
+		{"1.2.3-0123.0123", TypeInvalid, "", true},
+		{"1.1.2+.123", TypeInvalid, "", true},
+		{"+invalid", TypeInvalid, "", true},
+		{"-invalid", TypeInvalid, "", true},
+		{"alpha", TypeInvalid, "", true},
+		{"1.2.3.DEV", TypeInvalid, "", true},
+		{"1.2-SNAPSHOT", TypeInvalid, "", true},
+		{"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788", TypeInvalid, "", true},
+		{"-1.0.3-gamma+b7718", TypeInvalid, "", true},
+		{"+justmeta", TypeInvalid, "", true},
+		{"9.8.7+meta+meta", TypeInvalid, "", true},
+		{"9.8.7-whatever+meta+meta", TypeInvalid, "", true},
+		{"01.1.1", TypeInvalid, "", true},
+		{"1.01.1", TypeInvalid, "", true},
+		{"1.1.01", TypeInvalid, "", true},
+		{"1.0.0-alpha..1", TypeInvalid, "", true},
+		{"1.0.0-", TypeInvalid, "", true},
+		{"1.0.0+", TypeInvalid, "", true},
+		{"99999999999999999999999.999999999999999999.99999999999999999", TypeInvalid, "", true},
+
+		// Project extensions are not part of SemVer 2.0.0
+		{"v1.2.3", TypeInvalid, "", true},
+		{"1.2.3~alpha.1", TypeInvalid, "", true},
+		{"1.2.3.fix.1", TypeInvalid, "", true},
+		{"1.2.3_feature", TypeInvalid, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseWithOptions(test.input, DialectSemVer2)
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input %s, but got none", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for input %s: %v", test.input, err)
+			}
+			if result.Type != test.typ {
+				t.Errorf("Type mismatch for %s: expected %v, got %v", test.input, test.typ, result.Type)
+			}
+			if result.Prerelease != test.prerelease {
+				t.Errorf("Prerelease mismatch for %s: expected %s, got %s", test.input, test.prerelease, result.Prerelease)
+			}
+			if result.Dialect != DialectSemVer2 {
+				t.Errorf("Dialect mismatch for %s: expected semver2, got %v", test.input, result.Dialect)
+			}
+			if result.String() != test.input {
+				t.Errorf("String() for %s: got %s", test.input, result.String())
+			}
+		})
+	}
+}
+
+func TestParseWithOptionsExtended(t *testing.T) {
+	for _, input := range []string{"1.2.3", "v1.2.3-rc.1", "1.2.3.fix.1", "1.2.3_feature"} {
+		result, err := ParseWithOptions(input, DialectExtended)
+		if err != nil {
+			t.Errorf("Unexpected error for input %s: %v", input, err)
+			continue
+		}
+		expected, _ := Parse(input)
+		if Compare(result, expected) != 0 || result.String() != expected.String() {
+			t.Errorf("ParseWithOptions(%s, extended) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestCompareSemVer2Precedence(t *testing.T) {
+	// Ordered list from the SemVer 2.0.0 specification, section 11
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, err := ParseWithOptions(ordered[i], DialectSemVer2)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", ordered[i], err)
+			}
+			b, err := ParseWithOptions(ordered[j], DialectSemVer2)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", ordered[j], err)
+			}
+
+			result := Compare(a, b)
+			switch {
+			case i < j && result >= 0:
+				t.Errorf("Compare(%s, %s) = %d, want < 0", ordered[i], ordered[j], result)
+			case i > j && result <= 0:
+				t.Errorf("Compare(%s, %s) = %d, want > 0", ordered[i], ordered[j], result)
+			case i == j && result != 0:
+				t.Errorf("Compare(%s, %s) = %d, want 0", ordered[i], ordered[j], result)
+			}
+		}
+	}
+}
+
+func TestCompareSemVer2Identifiers(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"-alpha-1", "-alpha-2", -1},
+		{"-1", "-alpha", -1},
+		{"-99999999999999999999", "-100000000000000000000", -1},
+		{"-alpha.1", "-alpha.1", 0},
+		{"-Alpha", "-alpha", -1},
+		{"--x", "-x", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_vs_"+test.b, func(t *testing.T) {
+			result := compareSemVer2Identifiers(test.a, test.b)
+			if result != test.expected {
+				t.Errorf("compareSemVer2Identifiers(%s, %s): expected %d, got %d", test.a, test.b, test.expected, result)
+			}
+		})
+	}
+}
+
+func TestCompareSemVer2HyphenIdentifiers(t *testing.T) {
+	a, _ := ParseWithOptions("1.0.0--", DialectSemVer2)
+	b, _ := ParseWithOptions("1.0.0--.1", DialectSemVer2)
+	if Compare(a, b) >= 0 {
+		t.Errorf("Compare(1.0.0--, 1.0.0--.1) = %d, want < 0", Compare(a, b))
+	}
+	// Only the delimiter is trimmed, 1.0.0--x has the identifier -x
+	c, _ := ParseWithOptions("1.0.0--x", DialectSemVer2)
+	d, _ := ParseWithOptions("1.0.0-x", DialectSemVer2)
+	if Compare(c, d) >= 0 {
+		t.Errorf("Compare(1.0.0--x, 1.0.0-x) = %d, want < 0", Compare(c, d))
+	}
+}
+
+func TestParseWithOptionsSemVer2Build(t *testing.T) {
+	v, err := ParseWithOptions("1.0.0-rc.1+build.1-x", DialectSemVer2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v.Build != "+build.1-x" {
+		t.Errorf("Build = %s, want +build.1-x", v.Build)
+	}
+}
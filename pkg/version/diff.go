@@ -0,0 +1,192 @@
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Change is the version component that separates two versions
+type Change int
+
+const (
+	ChangeNone       Change = iota // Versions compare equal
+	ChangeMajor                    // Major versions differ
+	ChangeMinor                    // Minor versions differ
+	ChangePatch                    // Patch versions differ
+	ChangeRevision                 // Numeric segments after patch of N-part versions differ
+	ChangeType                     // Same core version, different version types
+	ChangeIdentifier               // Same core version and type, different identifiers
+)
+
+// String returns the string representation of a change
+func (c Change) String() string {
+	switch c {
+	case ChangeNone:
+		return "none"
+	case ChangeMajor:
+		return "major"
+	case ChangeMinor:
+		return "minor"
+	case ChangePatch:
+		return "patch"
+	case ChangeRevision:
+		return "revision"
+	case ChangeType:
+		return "type"
+	case ChangeIdentifier:
+		return "identifier"
+	default:
+		return "unknown"
+	}
+}
+
+// Direction is the ordering of the second version relative to the first
+type Direction int
+
+const (
+	DirectionNone Direction = iota // Versions compare equal
+	DirectionUp                    // The second version is greater
+	DirectionDown                  // The second version is lower
+)
+
+// String returns the string representation of a direction
+func (d Direction) String() string {
+	switch d {
+	case DirectionNone:
+		return "none"
+	case DirectionUp:
+		return "up"
+	case DirectionDown:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffResult describes what separates two versions
+type DiffResult struct {
+	From      string    // First version
+	To        string    // Second version
+	Change    Change    // Most significant component that differs
+	Direction Direction // Whether To is greater or lower than From
+	Delta     int       // To minus From of the changed numeric segment, 0 for other changes
+	FromType  Type      // Version type of From
+	ToType    Type      // Version type of To
+	Successor bool      // To is greater than From and is the result of a single Bump of From
+	BumpType  BumpType  // Bump that turns From into To, valid when Successor is set
+}
+
+// successorBumps are the bump types tried to find the bump that turns one
+// version into another, in the order they are reported
+var successorBumps = []BumpType{
+	BumpMajor, BumpMinor, BumpPatch, BumpRevision, BumpSmart, BumpRelease,
+	BumpAlpha, BumpBeta, BumpPre, BumpRc,
+	BumpFix, BumpNext, BumpPost, BumpFeat,
+}
+
+// Diff reports which component separates version a from version b, the
+// direction of the change and whether b is a valid successor of a, i.e. a
+// greater version produced by one of the Bump rules. Build metadata is
+// ignored as it is by Compare. Calendar successors are bumps on the date of
+// b, so the result does not depend on the current date.
+func Diff(a, b *Version) *DiffResult {
+	result := &DiffResult{
+		From:     a.String(),
+		To:       b.String(),
+		FromType: a.Type,
+		ToType:   b.Type,
+	}
+
+	c := Compare(a, b)
+	switch {
+	case a.Major != b.Major:
+		result.Change, result.Delta = ChangeMajor, b.Major-a.Major
+	case a.Minor != b.Minor:
+		result.Change, result.Delta = ChangeMinor, b.Minor-a.Minor
+	case a.Patch != b.Patch:
+		result.Change, result.Delta = ChangePatch, b.Patch-a.Patch
+	case compareExtra(a.Extra, b.Extra) != 0:
+		aSegments, bSegments := a.Segments(), b.Segments()
+		for i := 3; i < max(len(aSegments), len(bSegments)); i++ {
+			if delta := segmentAt(bSegments, i) - segmentAt(aSegments, i); delta != 0 {
+				result.Change, result.Delta = ChangeRevision, delta
+				break
+			}
+		}
+	case a.Type != b.Type:
+		result.Change = ChangeType
+	case c != 0:
+		result.Change = ChangeIdentifier
+	}
+
+	switch {
+	case c < 0:
+		result.Direction = DirectionUp
+	case c > 0:
+		result.Direction = DirectionDown
+	}
+
+	if result.Direction == DirectionUp {
+		result.BumpType, result.Successor = successorBump(a, b)
+	}
+	return result
+}
+
+// segmentAt returns segment i of the numeric segments of a version, 0 when missing
+func segmentAt(segments []int, i int) int {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
+
+// successorBump returns the bump type that turns version a into version b.
+// Calendar bumps are made on the date of b rather than today, so the result
+// only depends on the two versions.
+func successorBump(a, b *Version) (BumpType, bool) {
+	opts := BumpOptions{Scheme: versionScheme(a, b)}
+	if calver := opts.Scheme.calver(); calver != nil {
+		opts.Date = calver.date(b)
+	}
+
+	registry := opts.Scheme.labels()
+	bumps := slices.Clone(successorBumps)
+	for _, label := range registry.labels {
+		if _, builtin := builtinBumpTypes[label.Name]; !builtin {
+			bt, _ := registry.bumpType(label.Name)
+			bumps = append(bumps, bt)
+		}
+	}
+
+	for _, bt := range bumps {
+		bumped, err := BumpWithOptions(a.String(), bt, opts)
+		if err != nil {
+			continue
+		}
+		v, err := opts.Scheme.Parse(bumped.BumpedVersion)
+		if err == nil && Compare(v, b) == 0 {
+			return bt, true
+		}
+	}
+	return 0, false
+}
+
+// String returns a one line summary of the diff, e.g. "1.2.3 -> 1.4.0: minor +2 up"
+func (d *DiffResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s -> %s: %s", d.From, d.To, d.Change)
+	if d.Delta != 0 {
+		fmt.Fprintf(&b, " %+d", d.Delta)
+	}
+	if d.Change == ChangeType {
+		fmt.Fprintf(&b, " %s -> %s", d.FromType, d.ToType)
+	}
+	if d.Direction != DirectionNone {
+		fmt.Fprintf(&b, " %s", d.Direction)
+	}
+	if d.Successor {
+		fmt.Fprintf(&b, ", successor by %s bump", d.BumpType)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		change    Change
+		direction Direction
+		delta     int
+		successor bool
+		bumpType  BumpType
+	}{
+		{"1.2.3", "1.2.3", ChangeNone, DirectionNone, 0, false, 0},
+		{"1.2.3", "v1.2.3+build.1", ChangeNone, DirectionNone, 0, false, 0},
+		{"1.2.3", "2.0.0", ChangeMajor, DirectionUp, 1, true, BumpMajor},
+		{"1.2.3", "3.0.0", ChangeMajor, DirectionUp, 2, false, 0},
+		{"1.2.3", "1.3.0", ChangeMinor, DirectionUp, 1, true, BumpMinor},
+		{"1.2.3", "1.4.0", ChangeMinor, DirectionUp, 2, false, 0},
+		{"1.2.3", "1.3.1", ChangeMinor, DirectionUp, 1, false, 0},
+		{"1.2.3", "1.2.4", ChangePatch, DirectionUp, 1, true, BumpPatch},
+		{"1.2.4", "1.2.3", ChangePatch, DirectionDown, -1, false, 0},
+		{"1.2.3~rc.2", "1.2.3", ChangeType, DirectionUp, 0, true, BumpRelease},
+		{"1.2.3~alpha.4", "1.2.3~beta.1", ChangeIdentifier, DirectionUp, 0, true, BumpBeta},
+		{"1.2.3~alpha.4", "1.2.3~beta.2", ChangeIdentifier, DirectionUp, 0, false, 0},
+		{"1.2.3", "1.2.3~rc.1", ChangeType, DirectionDown, 0, false, 0},
+		{"1.2.3", "1.2.3.fix.1", ChangeType, DirectionUp, 0, true, BumpFix},
+		{"1.2.3", "1.2.3_feat.1", ChangeType, DirectionUp, 0, true, BumpFeat},
+		{"1.2.3~rc.1", "1.2.3~rc.2", ChangeIdentifier, DirectionUp, 0, true, BumpSmart},
+		{"1.2.3~rc.1", "1.2.3~rc.3", ChangeIdentifier, DirectionUp, 0, false, 0},
+		{"1.2.3~beta.2", "1.2.3~alpha.5", ChangeIdentifier, DirectionDown, 0, false, 0},
+		{"1.2.3.fix.1", "1.2.3.fix.2", ChangeIdentifier, DirectionUp, 0, true, BumpSmart},
+		{"1.2.3~rc.1+build.1", "1.2.3~rc.2+build.2", ChangeIdentifier, DirectionUp, 0, true, BumpSmart},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_"+test.b, func(t *testing.T) {
+			a, _ := Parse(test.a)
+			b, _ := Parse(test.b)
+			result := Diff(a, b)
+			if result.Change != test.change || result.Direction != test.direction || result.Delta != test.delta {
+				t.Errorf("Diff(%s, %s) = %s %s %d, want %s %s %d", test.a, test.b,
+					result.Change, result.Direction, result.Delta, test.change, test.direction, test.delta)
+			}
+			if result.Successor != test.successor || (test.successor && result.BumpType != test.bumpType) {
+				t.Errorf("Diff(%s, %s) successor = %v (%s), want %v (%s)", test.a, test.b,
+					result.Successor, result.BumpType, test.successor, test.bumpType)
+			}
+			if result.FromType != a.Type || result.ToType != b.Type {
+				t.Errorf("Diff(%s, %s) types = %s -> %s", test.a, test.b, result.FromType, result.ToType)
+			}
+		})
+	}
+}
+
+func TestDiffCustomLabels(t *testing.T) {
+	useLabels(t, orgLabels...)
+
+	a, _ := Parse("1.2.3")
+	b, _ := Parse("1.2.3.hotfix.1")
+	result := Diff(a, b)
+	if !result.Successor || result.BumpType.String() != "hotfix" {
+		t.Errorf("Diff(%s, %s) successor = %v (%s), want hotfix bump", a, b, result.Successor, result.BumpType)
+	}
+}
+
+func TestDiffCalVer(t *testing.T) {
+	// Successors are found on the date of the second version, not today
+	t.Setenv("SOURCE_DATE_EPOCH", "1893456000") // 2030-01-01 00:00:00 UTC
+
+	tests := []struct {
+		format    string
+		from      string
+		to        string
+		successor bool
+	}{
+		{"YY.0M.MICRO", "24.05.3", "24.05.4", true},
+		{"YY.0M.MICRO", "24.05.3", "24.06.0", true},
+		{"YY.0M.MICRO", "24.05.3", "24.05.5", false},
+		{"YY.0M.MICRO", "24.05.3", "24.06.1", false},
+		{"YYYY.MM.DD", "2024.5.16", "2024.5.17", true},
+		{"YYYY.WW", "2024.52", "2025.1", true},
+		{"0Y.0W.MICRO", "24.20.0", "24.20.1", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+"_"+test.from+"_"+test.to, func(t *testing.T) {
+			useCalVer(t, test.format)
+			a, _ := Parse(test.from)
+			b, _ := Parse(test.to)
+			result := Diff(a, b)
+			if result.Successor != test.successor {
+				t.Errorf("Diff(%s, %s) successor = %v (%s), want %v", a, b, result.Successor, result.BumpType, test.successor)
+			}
+		})
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{"1.2.3", "1.4.0", "1.2.3 -> 1.4.0: minor +2 up"},
+		{"1.2.3", "1.2.3~rc.1", "1.2.3 -> 1.2.3~rc.1: type release -> prerelease down"},
+		{"1.2.3~rc.1", "1.2.3~rc.2", "1.2.3~rc.1 -> 1.2.3~rc.2: identifier up, successor by smart bump"},
+		{"1.2.3", "1.2.3", "1.2.3 -> 1.2.3: none"},
+	}
+
+	for _, test := range tests {
+		a, _ := Parse(test.a)
+		b, _ := Parse(test.b)
+		if result := Diff(a, b).String(); result != test.expected {
+			t.Errorf("Diff(%s, %s).String() = %s, want %s", test.a, test.b, result, test.expected)
+		}
+	}
+}
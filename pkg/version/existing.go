@@ -0,0 +1,61 @@
+package version
+
+import (
+	"fmt"
+)
+
+// avoidExisting moves a bumped version past the versions that already exist as
+// tags: while the version is tagged, in either delimiter form (v1.4.0-rc.2 and
+// 1.4.0~rc.2 are the same version), the last bump type is applied to it again
+// (rc.2 -> rc.3, 1.3.0 -> 1.4.0 for a minor bump). It returns the first free
+// version and the versions that were skipped.
+func avoidExisting(version *Version, bumpType BumpType, opts BumpOptions) (*Version, []string, error) {
+	existing, err := existingVersions(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var skipped []string
+	for containsVersion(existing, version) {
+		next, _, err := bumpStep(version, bumpType, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bumped version %s already exists: %v", version, err)
+		}
+		if Compare(next, version) <= 0 {
+			return nil, nil, fmt.Errorf("bumped version %s already exists and %s bump does not advance it", version, bumpType)
+		}
+		skipped = append(skipped, version.String())
+		version = next
+	}
+	return version, skipped, nil
+}
+
+// existingVersions parses the existing version tags in opts.Tags, the tags of
+// GetTags() when opts.Tags is nil. Tags that are not valid versions are ignored.
+func existingVersions(opts BumpOptions) ([]*Version, error) {
+	tags := opts.Tags
+	if tags == nil {
+		var err error
+		if tags, err = GetTags(); err != nil && !IsNoGitTags(err) {
+			return nil, fmt.Errorf("failed to get existing tags: %v", err)
+		}
+	}
+
+	versions := make([]*Version, 0, len(tags))
+	for _, tag := range tags {
+		if v, err := opts.Scheme.Parse(tag); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// containsVersion reports whether versions contain a version equal to v
+func containsVersion(versions []*Version, v *Version) bool {
+	for _, existing := range versions {
+		if Equal(existing, v) {
+			return true
+		}
+	}
+	return false
+}
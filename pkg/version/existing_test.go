@@ -0,0 +1,62 @@
+package version
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBumpAvoidExisting(t *testing.T) {
+	tags := []string{"v1.3.0", "v1.4.0-rc.1", "v1.4.0-rc.2", "1.4.0~rc.3+build.7", "v1.2.4", "v1.2.3.fix.1", "not-a-version"}
+
+	tests := []struct {
+		version  string
+		spec     string
+		expected string
+		skipped  []string
+	}{
+		{"1.4.0~rc.1", "rc", "1.4.0~rc.4", []string{"1.4.0~rc.2", "1.4.0~rc.3"}},
+		{"1.4.0~rc.1", "smart", "1.4.0~rc.4", []string{"1.4.0~rc.2", "1.4.0~rc.3"}},
+		{"1.3.0", "rc", "1.3.0~rc.1", nil},
+		{"1.2.3", "minor", "1.4.0", []string{"1.3.0"}},
+		{"1.2.3", "patch", "1.2.5", []string{"1.2.4"}},
+		{"1.3.5", "minor+rc", "1.4.0~rc.4", []string{"1.4.0~rc.1", "1.4.0~rc.2", "1.4.0~rc.3"}},
+		{"1.2.3", "fix", "1.2.3.fix.2", []string{"1.2.3.fix.1"}},
+		{"1.2.3+build.5", "patch", "1.2.5+build.5", []string{"1.2.4"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+"_"+test.spec, func(t *testing.T) {
+			spec, err := ParseBumpSpec(test.spec)
+			if err != nil {
+				t.Fatalf("ParseBumpSpec(%s) failed: %v", test.spec, err)
+			}
+			result, err := BumpWithSpec(test.version, spec, BumpOptions{AvoidExisting: true, Tags: tags})
+			if err != nil {
+				t.Fatalf("BumpWithSpec(%s, %s) failed: %v", test.version, test.spec, err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("BumpWithSpec(%s, %s) = %s, want %s", test.version, test.spec, result.BumpedVersion, test.expected)
+			}
+			if !reflect.DeepEqual(result.Skipped, test.skipped) {
+				t.Errorf("Skipped = %v, want %v", result.Skipped, test.skipped)
+			}
+			if len(test.skipped) > 0 && !strings.Contains(result.AppliedRule, "skip existing "+strings.Join(test.skipped, ", ")) {
+				t.Errorf("AppliedRule = %q, want it to list the skipped versions", result.AppliedRule)
+			}
+		})
+	}
+
+	// Without AvoidExisting the bump stays arithmetic
+	if result, err := BumpWithOptions("1.4.0~rc.1", BumpRc, BumpOptions{Tags: tags}); err != nil || result.BumpedVersion != "1.4.0~rc.2" || result.Skipped != nil {
+		t.Errorf("BumpWithOptions(1.4.0~rc.1, rc) = %v, %v, want 1.4.0~rc.2", result, err)
+	}
+	// A finalized release that is already tagged cannot be moved
+	if _, err := BumpWithOptions("1.3.0~rc.2", BumpRelease, BumpOptions{AvoidExisting: true, Tags: tags}); err == nil {
+		t.Errorf("Expected error for a release that is already tagged")
+	}
+	// Bumps from commits honor the option
+	if result, err := BumpFromCommits("1.2.3", []Commit{{Hash: "abc1234", Message: "feat: x"}}, BumpOptions{AvoidExisting: true, Tags: tags}); err != nil || result.BumpedVersion != "1.4.0" {
+		t.Errorf("BumpFromCommits(1.2.3, feat) = %v, %v, want 1.4.0", result, err)
+	}
+}
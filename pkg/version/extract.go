@@ -0,0 +1,105 @@
+package version
+
+import (
+	"fmt"
+)
+
+// Match is a version found in free text by Extract or FindAll
+type Match struct {
+	Version *Version // Parsed version, '-' prerelease delimiter normalized to '~'
+	Text    string   // Version as written in the text, e.g. v1.2.3-rc.1
+	Offset  int      // Byte offset of Text in the text
+}
+
+// Extract returns the first valid version in free text such as file names
+// (myapp-1.4.2-linux-amd64.tar.gz), tool banners (cmake version 3.28.1) or
+// changelog headings. See FindAll for the matching rules.
+func Extract(text string) (*Match, error) {
+	return extractVersion(text, nil)
+}
+
+// extractVersion returns the first valid version of a scheme in text
+func extractVersion(text string, scheme *Scheme) (*Match, error) {
+	matches := findVersions(text, 1, scheme)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no version found in '%s'", text)
+	}
+	return &matches[0], nil
+}
+
+// FindAll returns every valid version in free text in the order they appear.
+//
+// A version starts with a digit that does not continue a number (the 1 of
+// go1.22.3 matches, the 2 of 1.2.3 does not) and an optional 'v' prefix that
+// starts a word. The longest valid version ending at a word boundary is taken:
+// 1.4.2-linux gives 1.4.2 as linux is not a prerelease label, while 1.4.2-rc.1
+// keeps its prerelease. Intermediate identifiers are not matched because '_'
+// separates words in file names (myapp_1.2.3_amd64.deb gives 1.2.3). Numbers
+// with more segments than the current scheme allows, such as IP addresses, are
+// skipped. The '-' prerelease delimiter is handled like ConvertGitTag.
+func FindAll(text string) []Match {
+	return findVersions(text, -1, nil)
+}
+
+// findVersions returns up to limit versions of a scheme found in text, all when
+// limit is negative
+func findVersions(text string, limit int, scheme *Scheme) []Match {
+	var matches []Match
+	for i := 0; i < len(text) && limit != 0; i++ {
+		if !isDigit(text[i]) || (i > 0 && (isDigit(text[i-1]) || text[i-1] == '.')) {
+			continue
+		}
+		start := i
+		if i > 0 && text[i-1] == 'v' && (i == 1 || !isAlpha(text[i-2])) {
+			start = i - 1
+		}
+		end := i
+		for end < len(text) && isVersionChar(text[end]) {
+			end++
+		}
+
+		match, ok := longestVersion(text, start, end, scheme)
+		if !ok {
+			continue
+		}
+		matches = append(matches, match)
+		i = match.Offset + len(match.Text) - 1
+		limit--
+	}
+	return matches
+}
+
+// longestVersion returns the longest valid version of text[start:end] that
+// starts at start and ends at a word boundary
+func longestVersion(text string, start, end int, scheme *Scheme) (Match, bool) {
+	for ; end > start; end-- {
+		if !versionBoundary(text, end) {
+			continue
+		}
+		v := &Version{}
+		if err := parseInto(v, text[start:end], scheme.calver(), scheme); err != nil || v.Type == TypeIntermediate {
+			continue
+		}
+		return Match{Version: v, Text: text[start:end], Offset: start}, true
+	}
+	return Match{}, false
+}
+
+// versionBoundary reports whether a version may end before offset i of text:
+// at the end of the text or before a character that does not continue a word or number
+func versionBoundary(text string, i int) bool {
+	switch {
+	case i == len(text):
+		return true
+	case isAlnum(text[i]):
+		return false
+	case text[i] == '.' && i+1 < len(text) && isDigit(text[i+1]):
+		return false
+	}
+	return true
+}
+
+// isVersionChar reports whether c may be part of a version
+func isVersionChar(c byte) bool {
+	return isAlnum(c) || c == '.' || c == '-' || c == '~' || c == '_' || c == '+'
+}
@@ -0,0 +1,75 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string // Text of each match
+		offsets  []int
+	}{
+		{"myapp-1.4.2-linux-amd64.tar.gz", []string{"1.4.2"}, []int{6}},
+		{"go1.22.3", []string{"1.22.3"}, []int{2}},
+		{"cmake version 3.28.1", []string{"3.28.1"}, []int{14}},
+		{"## [v2.0.0-rc.1] - 2024-05-17", []string{"v2.0.0-rc.1"}, []int{4}},
+		{"myapp-1.4.2-rc.1-linux.zip", []string{"1.4.2-rc.1"}, []int{6}},
+		{"myapp_1.2.3_amd64.deb", []string{"1.2.3"}, []int{6}},
+		{"release 1.2.3.fix.1 replaces 1.2.3.", []string{"1.2.3.fix.1", "1.2.3"}, []int{8, 29}},
+		{"upgrade 1.2.3 -> 1.3.0+build.5", []string{"1.2.3", "1.3.0+build.5"}, []int{8, 17}},
+		{"dev1.2.3", []string{"1.2.3"}, []int{3}},
+		{"Python 3.12 on 192.168.1.10", nil, nil},
+		{"1.2.3.4", nil, nil},
+		{"x1.2.3y", nil, nil},
+		{"", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			matches := FindAll(test.text)
+			if len(matches) != len(test.expected) {
+				t.Fatalf("FindAll(%q) = %v, want %v", test.text, matches, test.expected)
+			}
+			for i, match := range matches {
+				if match.Text != test.expected[i] || match.Offset != test.offsets[i] {
+					t.Errorf("FindAll(%q)[%d] = %s at %d, want %s at %d", test.text, i, match.Text, match.Offset, test.expected[i], test.offsets[i])
+				}
+				if test.text[match.Offset:match.Offset+len(match.Text)] != match.Text {
+					t.Errorf("Match %s does not refer to the text at offset %d", match.Text, match.Offset)
+				}
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	match, err := Extract("myapp-1.4.2-rc.1-linux-amd64.tar.gz")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if match.Version.String() != ConvertGitTag("1.4.2-rc.1") || match.Version.Type != TypePrerelease {
+		t.Errorf("Extract version = %s (%s), want %s", match.Version, match.Version.Type, ConvertGitTag("1.4.2-rc.1"))
+	}
+
+	if _, err := Extract("no version here 1.2"); err == nil {
+		t.Errorf("Expected error for text without a version")
+	}
+}
+
+func TestFindAllSchemes(t *testing.T) {
+	useLabels(t, orgLabels...)
+	if matches := FindAll("app-1.2.3.hotfix.1.tgz"); len(matches) != 1 || matches[0].Text != "1.2.3.hotfix.1" {
+		t.Errorf("FindAll with custom labels = %v, want 1.2.3.hotfix.1", matches)
+	}
+
+	useSegments(t, 4)
+	if matches := FindAll("setup-1.2.3.4.exe"); len(matches) != 1 || matches[0].Text != "1.2.3.4" {
+		t.Errorf("FindAll with 4 segments = %v, want 1.2.3.4", matches)
+	}
+
+	useCalVer(t, "YY.0M.MICRO")
+	if matches := FindAll("build 1.2.3 of 24.05.1"); len(matches) != 1 || matches[0].Text != "24.05.1" {
+		t.Errorf("FindAll with calver = %v, want 24.05.1", matches)
+	}
+}
@@ -81,6 +81,11 @@ func checkGitRepo() error {
 
 // checkGitTags verifies that the repository has at least one version tag
 func checkGitTags() error {
+    return checkModuleTags(Module{})
+}
+
+// checkModuleTags verifies that the repository has at least one version tag of a module
+func checkModuleTags(module Module) error {
     if err := checkGitAvailable(); err != nil {
         return err
     }
@@ -88,7 +93,7 @@ func checkGitTags() error {
         return err
     }
 
-    output, err := runGitCommand("tag", "-l", "v[0-9]*")
+    output, err := runGitCommand("tag", "-l", module.TagPattern())
     if err != nil {
         return err
     }
@@ -96,7 +101,7 @@ func checkGitTags() error {
     if len(strings.TrimSpace(output)) == 0 {
         return &GitError{
             Type:    "no_tags",
-            Message: "no version tags found - please create a version tag (e.g., v1.0.0)",
+            Message: fmt.Sprintf("no version tags found - please create a version tag (e.g., %s)", module.Tag("1.0.0")),
         }
     }
     return nil
@@ -130,21 +135,95 @@ func checkGitTags() error {
 //	}
 //	fmt.Printf("Current version: %s\n", version)
 func GetVersion() (string, error) {
-    if err := checkGitTags(); err != nil {
+    return GetModuleVersion(Module{})
+}
+
+// GetModuleVersion returns the current version of a module from its git tags,
+// e.g. 1.2.3 for the tag api/v1.2.3 of a module with the tag prefix api/.
+// Modules of one repository are versioned independently, see ProjectConfig.Module
+// for the modules declared in .project.yml.
+//
+// Example usage:
+//
+//	config, _ := version.NewConfigProvider().LoadProjectConfig()
+//	module, err := config.Module("api")
+//	if err != nil {
+//	    fmt.Printf("Error: %v\n", err)
+//	    return
+//	}
+//	apiVersion, err := version.GetModuleVersion(module)
+func GetModuleVersion(module Module) (string, error) {
+    if err := checkModuleTags(module); err != nil {
         return "", err
     }
 
-    output, err := runGitCommand("describe", "--match", "v[0-9]*", "--abbrev=0", "--tags", "HEAD")
+    output, err := runGitCommand("describe", "--match", module.TagPattern(), "--abbrev=0", "--tags", "HEAD")
     if err != nil {
         return "", fmt.Errorf("failed to get version from git: %v", err)
     }
     
-    versionStr := strings.TrimPrefix(output, "v")
+    versionStr := strings.TrimPrefix(strings.TrimPrefix(output, module.TagPrefix), "v")
     // Convert git tag format using the library
     versionStr = ConvertGitTag(versionStr)
     return versionStr, nil
 }
 
+// GetCommits returns the commits between the version tag GetVersion finds and HEAD,
+// newest first. BumpFromCommits chooses the bump of the next version from them.
+//
+// Example usage:
+//
+//	current, _ := version.GetVersion()
+//	commits, err := version.GetCommits()
+//	if err != nil {
+//	    fmt.Printf("Error: %v\n", err)
+//	    return
+//	}
+//	result, err := version.BumpFromCommits(current, commits, version.BumpOptions{})
+func GetCommits() ([]Commit, error) {
+    return GetModuleCommits(Module{})
+}
+
+// GetBranch returns the name of the current git branch, e.g. feature/PAY-123-refund.
+// A detached HEAD has no branch and is an error, CI checkouts pass the branch
+// name to BranchIdentifier or BumpOptions.Branch directly.
+func GetBranch() (string, error) {
+    if err := checkGitAvailable(); err != nil {
+        return "", err
+    }
+    if err := checkGitRepo(); err != nil {
+        return "", err
+    }
+
+    branch, err := runGitCommand("rev-parse", "--abbrev-ref", "HEAD")
+    if err != nil {
+        return "", fmt.Errorf("failed to get branch from git: %v", err)
+    }
+    if branch == "HEAD" {
+        return "", fmt.Errorf("no current branch, HEAD is detached")
+    }
+    return branch, nil
+}
+
+// GetModuleCommits returns the commits between the version tag GetModuleVersion
+// finds for a module and HEAD, newest first
+func GetModuleCommits(module Module) ([]Commit, error) {
+    if err := checkModuleTags(module); err != nil {
+        return nil, err
+    }
+
+    tag, err := runGitCommand("describe", "--match", module.TagPattern(), "--abbrev=0", "--tags", "HEAD")
+    if err != nil {
+        return nil, fmt.Errorf("failed to get version from git: %v", err)
+    }
+
+    output, err := runGitCommand("log", "--format=%h%x1f%B%x1e", tag+"..HEAD")
+    if err != nil {
+        return nil, fmt.Errorf("failed to get commits since %s: %v", tag, err)
+    }
+    return parseCommitLog(output), nil
+}
+
 // GetVersionWithPrefix returns the current project version from git tags with the 'v' prefix.
 // This is the same as GetVersion but preserves the 'v' prefix in the version string.
 // Git tag format is converted from x.y.z-(remainder) to x.y.z~(remainder) for consistency.
@@ -258,3 +337,92 @@ func GetRawVersion() (string, error) {
     return versionStr, nil
 }
 
+// GetTags returns all version tags (matching v[0-9]*) of the git repository
+// exactly as they appear in git.
+//
+// Returns an error if:
+//   - git is not available
+//   - not in a git repository
+//   - no version tags are found
+//   - git command execution fails
+func GetTags() ([]string, error) {
+    if err := checkGitTags(); err != nil {
+        return nil, err
+    }
+
+    output, err := runGitCommand("tag", "-l", "v[0-9]*")
+    if err != nil {
+        return nil, fmt.Errorf("failed to get tags from git: %v", err)
+    }
+
+    return strings.Split(output, "\n"), nil
+}
+
+// GetModuleTags returns the version tags of a module without the module tag
+// prefix (api/v1.2.3 -> v1.2.3), so they can be passed to Parse, Sort and FindLatest.
+func GetModuleTags(module Module) ([]string, error) {
+    if err := checkModuleTags(module); err != nil {
+        return nil, err
+    }
+
+    output, err := runGitCommand("tag", "-l", module.TagPattern())
+    if err != nil {
+        return nil, fmt.Errorf("failed to get tags from git: %v", err)
+    }
+
+    var tags []string
+    for _, tag := range strings.Split(output, "\n") {
+        if versionTag, ok := module.TrimTag(tag); ok {
+            tags = append(tags, versionTag)
+        }
+    }
+    return tags, nil
+}
+
+// GetLatestTag returns the highest git version tag that satisfies a constraint
+// expression (see ParseConstraint), skipping the version types excluded in opts.
+// The tag is returned exactly as it appears in git (e.g. "v1.4.3").
+//
+// Example usage:
+//
+//	tag, err := version.GetLatestTag("1.4.x", version.LatestOptions{
+//	    ExcludeTypes: []version.Type{version.TypePrerelease, version.TypeIntermediate},
+//	})
+//	if err != nil {
+//	    fmt.Printf("Error: %v\n", err)
+//	    return
+//	}
+//	fmt.Printf("Latest 1.4 tag: %s\n", tag) // e.g., "v1.4.3.fix.1"
+func GetLatestTag(constraintStr string, opts LatestOptions) (string, error) {
+    constraint, err := ParseConstraint(constraintStr)
+    if err != nil {
+        return "", err
+    }
+
+    tags, err := GetTags()
+    if err != nil {
+        return "", err
+    }
+
+    return FindLatest(tags, constraint, opts)
+}
+
+// GetModuleLatestTag returns the highest git version tag of a module that
+// satisfies a constraint expression, with the module tag prefix (e.g. "api/v1.4.3").
+func GetModuleLatestTag(module Module, constraintStr string, opts LatestOptions) (string, error) {
+    constraint, err := ParseConstraint(constraintStr)
+    if err != nil {
+        return "", err
+    }
+
+    tags, err := GetModuleTags(module)
+    if err != nil {
+        return "", err
+    }
+
+    latest, err := FindLatest(tags, constraint, opts)
+    if err != nil {
+        return "", err
+    }
+    return module.TagPrefix + latest, nil
+}
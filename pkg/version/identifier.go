@@ -0,0 +1,108 @@
+package version
+
+import "math"
+
+// maxIdentifierParts is the largest number of identifier parts cached on a Version
+const maxIdentifierParts = 8
+
+// identifierTokens are the parts of the type identifier of a parsed version,
+// split by Parse so that Compare does not scan the identifier again for every
+// comparison of a sort. The parts are kept as offsets into the identifier,
+// inline in the Version, so tokenizing does not allocate.
+type identifierTokens struct {
+	identifier string                    // identifier the parts were split from, empty when not tokenized
+	start      uint8                     // offset of the first part, after the label
+	n          uint8                     // number of parts
+	numeric    uint8                     // bit i is set when part i is numeric
+	ends       [maxIdentifierParts]uint8 // end offset of each part
+}
+
+// typeIdentifier returns the prerelease, postrelease or intermediate identifier of v
+func (v *Version) typeIdentifier() string {
+	switch v.Type {
+	case TypePrerelease:
+		return v.Prerelease
+	case TypePostrelease:
+		return v.Postrelease
+	case TypeIntermediate:
+		return v.Intermediate
+	default:
+		return ""
+	}
+}
+
+// tokenize splits the type identifier of v into its label and the parts that
+// compareIdentifiers scans. Releases, identifiers longer than 255 bytes and
+// identifiers with more than maxIdentifierParts parts are not tokenized.
+func tokenize(v *Version) identifierTokens {
+	identifier := v.typeIdentifier()
+	if identifier == "" || len(identifier) > math.MaxUint8 {
+		return identifierTokens{}
+	}
+	t := identifierTokens{identifier: identifier}
+	if v.Type != TypeIntermediate {
+		label, _ := splitLabel(identifier)
+		t.start = uint8(1 + len(label))
+	}
+	if int(t.start) == len(identifier) {
+		return t
+	}
+	for i := int(t.start); i <= len(identifier); {
+		if t.n == maxIdentifierParts {
+			return identifierTokens{}
+		}
+		part, next := nextIdentifierPart(identifier, i)
+		if isNumericIdentifier(part) {
+			t.numeric |= 1 << t.n
+		}
+		t.ends[t.n] = uint8(i + len(part))
+		t.n++
+		i = next
+	}
+	return t
+}
+
+// cachedTokens returns the tokens split by Parse, nil when v was not parsed
+// or its identifier has been changed since
+func (v *Version) cachedTokens() *identifierTokens {
+	if v.tokens.identifier == "" || v.Dialect != DialectExtended || v.tokens.identifier != v.typeIdentifier() {
+		return nil
+	}
+	return &v.tokens
+}
+
+// label returns the prerelease or postrelease label, empty for intermediate identifiers
+func (t *identifierTokens) label() string {
+	if t.start == 0 {
+		return ""
+	}
+	return t.identifier[1:t.start]
+}
+
+// part returns part i and whether it is numeric, an empty part after the last one
+func (t *identifierTokens) part(i int) (string, bool) {
+	if i >= int(t.n) {
+		return "", false
+	}
+	start := int(t.start)
+	if i > 0 {
+		start = int(t.ends[i-1]) + 1
+	}
+	return t.identifier[start:t.ends[i]], t.numeric&(1<<i) != 0
+}
+
+// compareTokens compares two tokenized identifiers of the same version type
+// like compareLabeled and compareIdentifiers
+func compareTokens(a, b *identifierTokens, scheme *Scheme) int {
+	if aLabel, bLabel := a.label(), b.label(); aLabel != bLabel {
+		return scheme.labels().compareLabels(aLabel, bLabel)
+	}
+	for i := 0; i < int(a.n) || i < int(b.n); i++ {
+		aPart, aNumeric := a.part(i)
+		bPart, bNumeric := b.part(i)
+		if result := compareParts(aPart, aNumeric, bPart, bNumeric); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package version
+
+import "testing"
+
+func TestCompareTokensMatchesIdentifiers(t *testing.T) {
+	var parsed, scanned []*Version
+	for _, group := range loadOrderingCorpus(t) {
+		for _, input := range group {
+			v, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", input, err)
+			}
+			if v.Type != TypeRelease && v.cachedTokens() == nil {
+				t.Errorf("Parse(%s) did not tokenize %s", input, v.typeIdentifier())
+			}
+			untokenized := *v
+			untokenized.tokens = identifierTokens{}
+			parsed = append(parsed, v)
+			scanned = append(scanned, &untokenized)
+		}
+	}
+
+	for i := range parsed {
+		for j := range parsed {
+			if got, want := Compare(parsed[i], parsed[j]), Compare(scanned[i], scanned[j]); got != want {
+				t.Errorf("Compare(%s, %s) = %d with tokens, %d without", parsed[i].Original, parsed[j].Original, got, want)
+			}
+		}
+	}
+}
+
+func TestCachedTokens(t *testing.T) {
+	v, err := Parse("1.2.3~rc.1_feature.2")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	tokens := v.cachedTokens()
+	if tokens == nil {
+		t.Fatalf("Parse(1.2.3~rc.1_feature.2) did not tokenize the prerelease")
+	}
+	if tokens.label() != "rc" {
+		t.Errorf("label() = %s, want rc", tokens.label())
+	}
+	var parts []string
+	for i := 0; i < int(tokens.n); i++ {
+		part, _ := tokens.part(i)
+		parts = append(parts, part)
+	}
+	if len(parts) != 4 || parts[0] != "" || parts[1] != "1" || parts[2] != "feature" || parts[3] != "2" {
+		t.Errorf("parts = %q, want [\"\" 1 feature 2]", parts)
+	}
+
+	// A changed identifier is compared by scanning it
+	changed := *v
+	changed.Prerelease = "~rc.10"
+	if changed.cachedTokens() != nil {
+		t.Errorf("cachedTokens() of a changed identifier is not nil")
+	}
+	if Compare(v, &changed) >= 0 {
+		t.Errorf("Compare(1.2.3~rc.1_feature.2, 1.2.3~rc.10) = %d, want < 0", Compare(v, &changed))
+	}
+
+	// Identifiers with more parts than are cached are scanned
+	long, err := Parse("1.2.3_a.1.2.3.4.5.6.7.8")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if long.cachedTokens() != nil {
+		t.Errorf("Parse(1.2.3_a.1.2.3.4.5.6.7.8) tokenized %d parts", long.tokens.n)
+	}
+}
@@ -0,0 +1,300 @@
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Label is a keyword that starts a prerelease or postrelease identifier,
+// e.g. alpha in 1.2.3~alpha.1 or fix in 1.2.3.fix.1
+type Label struct {
+	Name string // Keyword, lowercase letters only
+	Type Type   // TypePrerelease or TypePostrelease
+	Rank int    // Order among labels of the same type, lower ranks sort first
+}
+
+// builtinLabels are the labels of the version grammar with their ranks, this is
+// the precedence table of ordering contract 1 (docs/Ordering.md):
+// alpha < beta < pre < rc and fix < next < post.
+// Ranks are spaced so that custom labels can be placed in between.
+var builtinLabels = []Label{
+	{Name: "alpha", Type: TypePrerelease, Rank: 100},
+	{Name: "beta", Type: TypePrerelease, Rank: 200},
+	{Name: "rc", Type: TypePrerelease, Rank: 400},
+	{Name: "pre", Type: TypePrerelease, Rank: 300},
+	{Name: "fix", Type: TypePostrelease, Rank: 100},
+	{Name: "next", Type: TypePostrelease, Rank: 200},
+	{Name: "post", Type: TypePostrelease, Rank: 300},
+}
+
+// builtinBumpTypes maps built-in labels to their bump types
+var builtinBumpTypes = map[string]BumpType{
+	"alpha": BumpAlpha,
+	"beta":  BumpBeta,
+	"rc":    BumpRc,
+	"pre":   BumpPre,
+	"fix":   BumpFix,
+	"next":  BumpNext,
+	"post":  BumpPost,
+}
+
+// bumpLabelBase is the first bump type used for custom labels,
+// the bump type of a custom label is bumpLabelBase plus its registry index
+const bumpLabelBase BumpType = 1000
+
+// LabelRegistry is an immutable set of prerelease and postrelease labels.
+// Parse, Compare, Bump and ParseBumpType use the registry set with SetLabelRegistry.
+type LabelRegistry struct {
+	labels []Label
+	index  map[string]int
+}
+
+// DefaultLabelRegistry returns a registry with the built-in labels
+// alpha, beta, rc, pre (prerelease) and fix, next, post (postrelease)
+func DefaultLabelRegistry() *LabelRegistry {
+	return defaultLabelRegistry
+}
+
+var defaultLabelRegistry = mustLabelRegistry(builtinLabels)
+
+// currentLabelRegistry is the registry used by Parse, Compare and Bump
+var currentLabelRegistry atomic.Pointer[LabelRegistry]
+
+// CurrentLabelRegistry returns the registry used by Parse, Compare and Bump
+func CurrentLabelRegistry() *LabelRegistry {
+	if r := currentLabelRegistry.Load(); r != nil {
+		return r
+	}
+	return defaultLabelRegistry
+}
+
+// SetLabelRegistry sets the registry used by Parse, Compare and Bump.
+// A nil registry restores the default registry.
+func SetLabelRegistry(r *LabelRegistry) {
+	currentLabelRegistry.Store(r)
+}
+
+// RegisterLabels adds labels to the current registry
+func RegisterLabels(labels ...Label) error {
+	return updateLabelRegistry(func(r *LabelRegistry) (*LabelRegistry, error) {
+		return r.With(labels...)
+	})
+}
+
+// SetLabelPrecedence overrides the precedence of the labels of a version type
+// in the current registry, see LabelRegistry.WithPrecedence
+func SetLabelPrecedence(t Type, names ...string) error {
+	return updateLabelRegistry(func(r *LabelRegistry) (*LabelRegistry, error) {
+		return r.WithPrecedence(t, names...)
+	})
+}
+
+// updateLabelRegistry atomically replaces the current registry with the result of update
+func updateLabelRegistry(update func(*LabelRegistry) (*LabelRegistry, error)) error {
+	for {
+		current := currentLabelRegistry.Load()
+		base := current
+		if base == nil {
+			base = defaultLabelRegistry
+		}
+		r, err := update(base)
+		if err != nil {
+			return err
+		}
+		if currentLabelRegistry.CompareAndSwap(current, r) {
+			return nil
+		}
+	}
+}
+
+// With returns a new registry with the labels of r and the given labels.
+// Labels must have a unique lowercase name and a prerelease or postrelease type.
+func (r *LabelRegistry) With(labels ...Label) (*LabelRegistry, error) {
+	combined := make([]Label, 0, len(r.labels)+len(labels))
+	combined = append(combined, r.labels...)
+	combined = append(combined, labels...)
+	return newLabelRegistry(combined)
+}
+
+// WithPrecedence returns a new registry in which the labels of a version type
+// sort in the given order. Names must list every label of the type exactly once,
+// their ranks are reassigned as 100, 200, 300 and so on.
+func (r *LabelRegistry) WithPrecedence(t Type, names ...string) (*LabelRegistry, error) {
+	if t != TypePrerelease && t != TypePostrelease {
+		return nil, fmt.Errorf("precedence: type must be prerelease or postrelease, got %s", t)
+	}
+	if want := r.names(t); len(names) != len(want) {
+		return nil, fmt.Errorf("precedence: %s order must list all labels (%s)", t, strings.Join(want, ", "))
+	}
+
+	labels := make([]Label, len(r.labels))
+	copy(labels, r.labels)
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		j, ok := r.index[name]
+		if !ok || labels[j].Type != t {
+			return nil, fmt.Errorf("precedence: %s is not a %s label", name, t)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("precedence: %s is listed twice", name)
+		}
+		seen[name] = true
+		labels[j].Rank = (i + 1) * 100
+	}
+	return newLabelRegistry(labels)
+}
+
+// Precedence returns the label names of a version type in ascending order
+func (r *LabelRegistry) Precedence(t Type) []string {
+	names := r.names(t)
+	slices.SortStableFunc(names, r.compareLabels)
+	return names
+}
+
+// Lookup returns the label with the given name
+func (r *LabelRegistry) Lookup(name string) (Label, bool) {
+	i, ok := r.index[name]
+	if !ok {
+		return Label{}, false
+	}
+	return r.labels[i], true
+}
+
+// Labels returns the labels of a version type in registration order
+func (r *LabelRegistry) Labels(t Type) []Label {
+	var labels []Label
+	for _, label := range r.labels {
+		if label.Type == t {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// names returns the label names of a version type in registration order
+func (r *LabelRegistry) names(t Type) []string {
+	var names []string
+	for _, label := range r.labels {
+		if label.Type == t {
+			names = append(names, label.Name)
+		}
+	}
+	return names
+}
+
+// compareLabels compares two label names of the same type by rank.
+// Labels with the same rank and unknown labels are compared lexically.
+func (r *LabelRegistry) compareLabels(a, b string) int {
+	if a == b {
+		return 0
+	}
+	aLabel, aOK := r.Lookup(a)
+	bLabel, bOK := r.Lookup(b)
+	if aOK && bOK && aLabel.Rank != bLabel.Rank {
+		return sign(aLabel.Rank - bLabel.Rank)
+	}
+	return strings.Compare(a, b)
+}
+
+// ordered reports whether the given labels sort in ascending order
+func (r *LabelRegistry) ordered(names ...string) bool {
+	for i := 1; i < len(names); i++ {
+		if r.compareLabels(names[i-1], names[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// lexicalOrder reports whether the ranks of the labels of a version type
+// agree with their lexical order, which is the order other ecosystems use
+func (r *LabelRegistry) lexicalOrder(t Type) bool {
+	labels := r.Labels(t)
+	for i := range labels {
+		for j := range labels {
+			if labels[i].Name < labels[j].Name && labels[i].Rank > labels[j].Rank {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// bumpType returns the bump type of a label
+func (r *LabelRegistry) bumpType(name string) (BumpType, bool) {
+	i, ok := r.index[name]
+	if !ok {
+		return 0, false
+	}
+	if bt, ok := builtinBumpTypes[name]; ok {
+		return bt, true
+	}
+	return bumpLabelBase + BumpType(i), true
+}
+
+// bumpLabel returns the custom label of a bump type
+func (r *LabelRegistry) bumpLabel(bt BumpType) (Label, bool) {
+	i := int(bt - bumpLabelBase)
+	if bt < bumpLabelBase || i >= len(r.labels) {
+		return Label{}, false
+	}
+	return r.labels[i], true
+}
+
+// newLabelRegistry validates labels and builds a registry
+func newLabelRegistry(labels []Label) (*LabelRegistry, error) {
+	r := &LabelRegistry{labels: labels, index: make(map[string]int, len(labels))}
+	for i, label := range labels {
+		if label.Name == "" {
+			return nil, fmt.Errorf("label %d: name is required", i+1)
+		}
+		for j := 0; j < len(label.Name); j++ {
+			if label.Name[j] < 'a' || label.Name[j] > 'z' {
+				return nil, fmt.Errorf("label %s: name must consist of lowercase letters", label.Name)
+			}
+		}
+		if label.Type != TypePrerelease && label.Type != TypePostrelease {
+			return nil, fmt.Errorf("label %s: type must be prerelease or postrelease, got %s", label.Name, label.Type)
+		}
+		if _, ok := r.index[label.Name]; ok {
+			return nil, fmt.Errorf("label %s: already registered", label.Name)
+		}
+		r.index[label.Name] = i
+	}
+	return r, nil
+}
+
+// mustLabelRegistry builds a registry and panics on invalid labels
+func mustLabelRegistry(labels []Label) *LabelRegistry {
+	r, err := newLabelRegistry(labels)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// splitLabel splits a prerelease or postrelease identifier into its label
+// and the remaining suffix, e.g. "~rc.1_x" -> "rc", ".1_x"
+func splitLabel(identifier string) (string, string) {
+	if identifier == "" {
+		return "", ""
+	}
+	i := 1
+	for i < len(identifier) && isAlpha(identifier[i]) {
+		i++
+	}
+	return identifier[1:i], identifier[i:]
+}
+
+// compareLabeled compares two prerelease or postrelease identifiers,
+// first by label rank in the registry of scheme and then by the remaining identifiers
+func compareLabeled(a, b string, scheme *Scheme) int {
+	aLabel, aRest := splitLabel(a)
+	bLabel, bRest := splitLabel(b)
+	if aLabel != bLabel {
+		return scheme.labels().compareLabels(aLabel, bLabel)
+	}
+	return compareIdentifiers(aRest, bRest)
+}
@@ -0,0 +1,400 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// orgLabels are custom labels used by the tests below
+var orgLabels = []Label{
+	{Name: "dev", Type: TypePrerelease, Rank: 50},
+	{Name: "preview", Type: TypePrerelease, Rank: 250},
+	{Name: "hotfix", Type: TypePostrelease, Rank: 150},
+	{Name: "patch", Type: TypePostrelease, Rank: 400},
+}
+
+// useLabels registers labels for the duration of a test
+func useLabels(t *testing.T, labels ...Label) {
+	t.Helper()
+	if err := RegisterLabels(labels...); err != nil {
+		t.Fatalf("RegisterLabels failed: %v", err)
+	}
+	t.Cleanup(func() { SetLabelRegistry(nil) })
+}
+
+func TestLabelRegistryValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		label Label
+	}{
+		{"empty name", Label{Name: "", Type: TypePrerelease}},
+		{"uppercase name", Label{Name: "Dev", Type: TypePrerelease}},
+		{"digits in name", Label{Name: "dev2", Type: TypePrerelease}},
+		{"release type", Label{Name: "dev", Type: TypeRelease}},
+		{"intermediate type", Label{Name: "dev", Type: TypeIntermediate}},
+		{"duplicate built-in", Label{Name: "rc", Type: TypePostrelease}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := DefaultLabelRegistry().With(test.label); err == nil {
+				t.Errorf("Expected error registering %+v", test.label)
+			}
+		})
+	}
+
+	if _, err := DefaultLabelRegistry().With(orgLabels[0], orgLabels[0]); err == nil {
+		t.Errorf("Expected error registering a label twice")
+	}
+}
+
+func TestLabelRegistryLookup(t *testing.T) {
+	r, err := DefaultLabelRegistry().With(orgLabels...)
+	if err != nil {
+		t.Fatalf("With failed: %v", err)
+	}
+
+	if label, ok := r.Lookup("preview"); !ok || label.Type != TypePrerelease || label.Rank != 250 {
+		t.Errorf("Lookup(preview) = %+v, %v", label, ok)
+	}
+	if _, ok := DefaultLabelRegistry().Lookup("preview"); ok {
+		t.Errorf("With modified the default registry")
+	}
+
+	var names []string
+	for _, label := range r.Labels(TypePostrelease) {
+		names = append(names, label.Name)
+	}
+	if strings.Join(names, ",") != "fix,next,post,hotfix,patch" {
+		t.Errorf("Labels(postrelease) = %v", names)
+	}
+}
+
+func TestLabelPrecedence(t *testing.T) {
+	if got := strings.Join(DefaultLabelRegistry().Precedence(TypePrerelease), ","); got != "alpha,beta,pre,rc" {
+		t.Errorf("Precedence(prerelease) = %s, want alpha,beta,pre,rc", got)
+	}
+	if got := strings.Join(DefaultLabelRegistry().Precedence(TypePostrelease), ","); got != "fix,next,post" {
+		t.Errorf("Precedence(postrelease) = %s, want fix,next,post", got)
+	}
+
+	r, err := DefaultLabelRegistry().With(orgLabels...)
+	if err != nil {
+		t.Fatalf("With failed: %v", err)
+	}
+	r, err = r.WithPrecedence(TypePostrelease, "post", "hotfix", "fix", "next", "patch")
+	if err != nil {
+		t.Fatalf("WithPrecedence failed: %v", err)
+	}
+	if got := strings.Join(r.Precedence(TypePostrelease), ","); got != "post,hotfix,fix,next,patch" {
+		t.Errorf("Precedence(postrelease) = %s", got)
+	}
+	if got := strings.Join(r.Precedence(TypePrerelease), ","); got != "dev,alpha,beta,preview,pre,rc" {
+		t.Errorf("Precedence(prerelease) = %s", got)
+	}
+	if label, _ := r.Lookup("fix"); label.Rank != 300 {
+		t.Errorf("Lookup(fix).Rank = %d, want 300", label.Rank)
+	}
+
+	tests := []struct {
+		name      string
+		labelType Type
+		names     []string
+	}{
+		{"missing label", TypePrerelease, []string{"alpha", "beta", "rc"}},
+		{"unknown label", TypePrerelease, []string{"alpha", "beta", "rc", "dev"}},
+		{"label of other type", TypePrerelease, []string{"alpha", "beta", "rc", "fix"}},
+		{"duplicate label", TypePrerelease, []string{"alpha", "beta", "rc", "rc"}},
+		{"release type", TypeRelease, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := DefaultLabelRegistry().WithPrecedence(test.labelType, test.names...); err == nil {
+				t.Errorf("Expected error for precedence %v", test.names)
+			}
+		})
+	}
+}
+
+func TestCompareLabelPrecedence(t *testing.T) {
+	if err := SetLabelPrecedence(TypePrerelease, "alpha", "beta", "rc", "pre"); err != nil {
+		t.Fatalf("SetLabelPrecedence failed: %v", err)
+	}
+	t.Cleanup(func() { SetLabelRegistry(nil) })
+
+	sorted, err := Sort([]string{"1.2.3", "1.2.3~pre.1", "1.2.3~rc.2", "1.2.3~beta.1", "1.2.3~rc.1"})
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	expected := "1.2.3~beta.1,1.2.3~rc.1,1.2.3~rc.2,1.2.3~pre.1,1.2.3"
+	if strings.Join(sorted, ",") != expected {
+		t.Errorf("Sort() = %v, want %s", sorted, expected)
+	}
+
+	// pre after rc has no equivalent in lexical targets, alpha < beta < rc still converts
+	v, _ := Parse("1.2.3~rc.1")
+	if _, err := Convert(v, TargetDeb); err == nil {
+		t.Errorf("Expected error converting %s to deb", v)
+	}
+	if result, err := Convert(v, TargetPEP440); err != nil || result != "1.2.3rc1" {
+		t.Errorf("Convert(pep440) = %s, %v", result, err)
+	}
+
+	SetLabelRegistry(nil)
+	if err := SetLabelPrecedence(TypePrerelease, "rc", "beta", "alpha", "pre"); err != nil {
+		t.Fatalf("SetLabelPrecedence failed: %v", err)
+	}
+	for _, target := range []Target{TargetPEP440, TargetMaven} {
+		if _, err := Convert(v, target); err == nil {
+			t.Errorf("Expected error converting %s to %s with reversed precedence", v, target)
+		}
+	}
+}
+
+func TestParseCustomLabels(t *testing.T) {
+	if IsValid("1.2.3~dev.1") {
+		t.Fatalf("1.2.3~dev.1 is valid without registered labels")
+	}
+	useLabels(t, orgLabels...)
+
+	tests := []struct {
+		input    string
+		expected Type
+		valid    bool
+	}{
+		{"1.2.3~dev.1", TypePrerelease, true},
+		{"v1.2.3-preview.2", TypePrerelease, true},
+		{"1.2.3.hotfix.1", TypePostrelease, true},
+		{"1.2.3.patch", TypePostrelease, true},
+		{"1.2.3.dev.1", TypeInvalid, false},
+		{"1.2.3~hotfix", TypeInvalid, false},
+		{"1.2.3~nightly", TypeInvalid, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := GetType(test.input)
+			if (err == nil) != test.valid {
+				t.Fatalf("GetType(%s) error = %v, valid %v", test.input, err, test.valid)
+			}
+			if result != test.expected {
+				t.Errorf("GetType(%s) = %s, want %s", test.input, result, test.expected)
+			}
+		})
+	}
+
+	_, err := Parse("1.2.3~nightly")
+	if err == nil || !strings.Contains(err.Error(), "(alpha, beta, rc, pre, dev, preview)") {
+		t.Errorf("Parse error = %v, want registered labels listed", err)
+	}
+
+	_, err = Parse("1.2.3~Preview1")
+	if parseErr, ok := err.(*ParseError); !ok || parseErr.Suggestion != "1.2.3~preview.1" {
+		t.Errorf("Parse error = %v, want suggestion 1.2.3~preview.1", err)
+	}
+}
+
+func TestCompareCustomLabels(t *testing.T) {
+	useLabels(t, orgLabels...)
+
+	sorted := []string{
+		"1.2.3~dev.1",
+		"1.2.3~dev.2",
+		"1.2.3~alpha.1",
+		"1.2.3~beta.1",
+		"1.2.3~preview.1",
+		"1.2.3~pre.1",
+		"1.2.3~rc.1",
+		"1.2.3",
+		"1.2.3.fix.1",
+		"1.2.3.hotfix",
+		"1.2.3.hotfix.1",
+		"1.2.3.next.1",
+		"1.2.3.post.1",
+		"1.2.3.patch.1",
+		"1.2.3_feat",
+	}
+
+	reversed := make([]string, len(sorted))
+	for i, v := range sorted {
+		reversed[len(sorted)-1-i] = v
+	}
+	result, err := Sort(reversed)
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	for i := range sorted {
+		if result[i] != sorted[i] {
+			t.Errorf("Sort()[%d] = %s, want %s", i, result[i], sorted[i])
+		}
+	}
+}
+
+func TestBumpCustomLabels(t *testing.T) {
+	useLabels(t, orgLabels...)
+
+	tests := []struct {
+		input    string
+		bumpType string
+		expected string
+	}{
+		{"1.2.3", "dev", "1.2.3~dev.1"},
+		{"1.2.3", "Preview", "1.2.3~preview.1"},
+		{"1.2.3~dev.1", "dev", "1.2.3~dev.2"},
+		{"1.2.3", "hotfix", "1.2.3.hotfix.1"},
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3", "label:patch", "1.2.3.patch.1"},
+		{"1.2.3", "label:alpha", "1.2.3~alpha.1"},
+		{"1.2.3.hotfix.1", "smart", "1.2.3.hotfix.2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"_"+test.bumpType, func(t *testing.T) {
+			bumpType, err := ParseBumpType(test.bumpType)
+			if err != nil {
+				t.Fatalf("ParseBumpType(%s) failed: %v", test.bumpType, err)
+			}
+			result, err := Bump(test.input, bumpType)
+			if err != nil {
+				t.Fatalf("Bump failed: %v", err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("Bump(%s, %s) = %s, want %s", test.input, test.bumpType, result.BumpedVersion, test.expected)
+			}
+		})
+	}
+
+	bumpType, _ := ParseBumpType("hotfix")
+	if bumpType.String() != "hotfix" {
+		t.Errorf("BumpType.String() = %s, want hotfix", bumpType)
+	}
+	if _, err := ParseBumpType("nightly"); err == nil {
+		t.Errorf("Expected error for unregistered label")
+	}
+}
+
+func TestConvertCustomLabels(t *testing.T) {
+	useLabels(t, Label{Name: "preview", Type: TypePrerelease, Rank: 350})
+
+	// preview sorts between pre and rc, which agrees with the lexical order
+	v, _ := Parse("1.2.3~preview.1")
+	if result, err := Convert(v, TargetDeb); err != nil || result != "1.2.3~preview.1" {
+		t.Errorf("Convert(deb) = %s, %v", result, err)
+	}
+
+	SetLabelRegistry(nil)
+	useLabels(t, orgLabels...)
+
+	// dev sorts before alpha, which deb and npm cannot represent
+	v, _ = Parse("1.2.3~dev.1")
+	for _, target := range []Target{TargetDeb, TargetRPM, TargetNPM, TargetNuGet, TargetPEP440} {
+		if _, err := Convert(v, target); err == nil {
+			t.Errorf("Expected error converting %s to %s", v, target)
+		}
+	}
+	v, _ = Parse("1.2.3~rc.1")
+	if result, err := Convert(v, TargetPEP440); err != nil || result != "1.2.3rc1" {
+		t.Errorf("Convert(pep440) = %s, %v", result, err)
+	}
+}
+
+func TestProjectConfigLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  string
+		wantErr bool
+	}{
+		{"valid labels", `
+    - name: dev
+      type: prerelease
+      rank: 50
+    - name: hotfix
+      type: postrelease
+      rank: 150`, false},
+		{"unknown type", `
+    - name: dev
+      type: nightly`, true},
+		{"intermediate type", `
+    - name: dev
+      type: intermediate`, true},
+		{"duplicate built-in", `
+    - name: fix
+      type: postrelease`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\nversion:\n  labels:" + tt.labels + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("GetProjectConfigFromFile failed: %v", err)
+			}
+			r, err := config.LabelRegistry()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LabelRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if label, ok := r.Lookup("hotfix"); !ok || label.Type != TypePostrelease || label.Rank != 150 {
+					t.Errorf("Lookup(hotfix) = %+v, %v", label, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectConfigPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		precedence string
+		wantErr    bool
+	}{
+		{"built-in labels", `
+  precedence:
+    prerelease: [alpha, beta, rc, pre]`, "alpha,beta,rc,pre", false},
+		{"custom labels", `
+  labels:
+    - name: dev
+      type: prerelease
+  precedence:
+    prerelease: [dev, alpha, beta, pre, rc]`, "dev,alpha,beta,pre,rc", false},
+		{"missing label", `
+  precedence:
+    prerelease: [alpha, beta, rc]`, "", true},
+		{"unknown label", `
+  precedence:
+    postrelease: [fix, next, post, hotfix]`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\nversion:" + tt.version + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("GetProjectConfigFromFile failed: %v", err)
+			}
+			r, err := config.LabelRegistry()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LabelRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if got := strings.Join(r.Precedence(TypePrerelease), ","); got != tt.precedence {
+					t.Errorf("Precedence(prerelease) = %s, want %s", got, tt.precedence)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package version
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalText implements encoding.TextMarshaler, the version is encoded as its string form
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with the
+// dialect already set on v, which is the extended grammar for a zero Version.
+// Invalid versions are rejected with the parse error.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := ParseWithOptions(string(text), v.Dialect)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the version is encoded as a JSON string
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves v unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("version must be a JSON string: %v", err)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements yaml.Marshaler, the version is encoded as a YAML string
+func (v Version) MarshalYAML() (interface{}, error) {
+	return v.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler for scalar version nodes
+func (v *Version) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: version must be a scalar", value.Line)
+	}
+	if err := v.UnmarshalText([]byte(value.Value)); err != nil {
+		return fmt.Errorf("line %d: %v", value.Line, err)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, the version is stored as its string form
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan implements sql.Scanner for string and []byte column values.
+// NULL is rejected, use sql.Null[Version] for nullable columns.
+func (v *Version) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into version.Version")
+	default:
+		return fmt.Errorf("cannot scan %T into version.Version", src)
+	}
+}
@@ -0,0 +1,219 @@
+package version
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Version)(nil)
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+	_ json.Marshaler           = (*Version)(nil)
+	_ json.Unmarshaler         = (*Version)(nil)
+	_ yaml.Marshaler           = (*Version)(nil)
+	_ yaml.Unmarshaler         = (*Version)(nil)
+	_ sql.Scanner              = (*Version)(nil)
+	_ driver.Valuer            = (*Version)(nil)
+)
+
+// marshalCorpus is a list of versions that must round-trip through every encoding
+var marshalCorpus = []string{
+	"1.2.3",
+	"v1.2.3",
+	"1.2.3~alpha.1_feature",
+	"1.2.3.fix.2",
+	"1.2.3_feat.1",
+	"1.2.3~rc.1+build.42",
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, input := range marshalCorpus {
+		t.Run(input, func(t *testing.T) {
+			v, _ := Parse(input)
+			text, err := v.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText failed: %v", err)
+			}
+			if string(text) != input {
+				t.Errorf("MarshalText = %s, want %s", text, input)
+			}
+
+			var decoded Version
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText failed: %v", err)
+			}
+			if decoded != *v {
+				t.Errorf("UnmarshalText = %+v, want %+v", decoded, *v)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextInvalid(t *testing.T) {
+	v := Version{Major: 9}
+	err := v.UnmarshalText([]byte("1.2.3-RC1"))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("UnmarshalText error = %v, want *ParseError", err)
+	}
+	if v.Major != 9 {
+		t.Errorf("UnmarshalText modified the version on error: %+v", v)
+	}
+}
+
+func TestUnmarshalTextDialect(t *testing.T) {
+	v := Version{Dialect: DialectSemVer2}
+	if err := v.UnmarshalText([]byte("1.0.0-alpha.beta")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if v.Dialect != DialectSemVer2 || v.Prerelease != "-alpha.beta" {
+		t.Errorf("UnmarshalText = %+v, want SemVer 2.0.0 prerelease -alpha.beta", v)
+	}
+}
+
+type releaseRecord struct {
+	Name    string   `json:"name" yaml:"name"`
+	Version Version  `json:"version" yaml:"version"`
+	Latest  *Version `json:"latest,omitempty" yaml:"latest,omitempty"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	latest, _ := Parse("1.3.0~rc.1")
+	record := releaseRecord{Name: "app", Latest: latest}
+	record.Version.UnmarshalText([]byte("1.2.3.fix.1"))
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	expected := `{"name":"app","version":"1.2.3.fix.1","latest":"1.3.0~rc.1"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal = %s, want %s", data, expected)
+	}
+
+	var decoded releaseRecord
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if decoded.Version != record.Version || *decoded.Latest != *record.Latest {
+		t.Errorf("json.Unmarshal = %+v, want %+v", decoded, record)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	tests := []string{
+		`{"version":"1.2"}`,
+		`{"version":"1.2.3-RC1"}`,
+		`{"version":123}`,
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			var record releaseRecord
+			if err := json.Unmarshal([]byte(input), &record); err == nil {
+				t.Errorf("Expected error decoding %s", input)
+			}
+		})
+	}
+
+	var record releaseRecord
+	if err := json.Unmarshal([]byte(`{"version":null}`), &record); err != nil {
+		t.Errorf("Unexpected error decoding null: %v", err)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	latest, _ := Parse("1.3.0~rc.1")
+	record := releaseRecord{Name: "app", Latest: latest}
+	record.Version.UnmarshalText([]byte("v1.2.3+build.5"))
+
+	data, err := yaml.Marshal(record)
+	if err != nil {
+		t.Fatalf("yaml.Marshal failed: %v", err)
+	}
+	expected := "name: app\nversion: v1.2.3+build.5\nlatest: 1.3.0~rc.1\n"
+	if string(data) != expected {
+		t.Errorf("yaml.Marshal = %q, want %q", data, expected)
+	}
+
+	var decoded releaseRecord
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	if decoded.Version != record.Version || *decoded.Latest != *record.Latest {
+		t.Errorf("yaml.Unmarshal = %+v, want %+v", decoded, record)
+	}
+}
+
+func TestUnmarshalYAMLInvalid(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"version: 1.2\n", "line 1: invalid version format: 1.2"},
+		{"name: app\nversion: 1.2.3~beta1\n", "line 2: invalid version format: 1.2.3~beta1"},
+		{"version: [1, 2, 3]\n", "line 1: version must be a scalar"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var record releaseRecord
+			err := yaml.Unmarshal([]byte(test.input), &record)
+			if err == nil || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("yaml.Unmarshal error = %v, want %s", err, test.message)
+			}
+		})
+	}
+}
+
+func TestSQLValueScan(t *testing.T) {
+	for _, input := range marshalCorpus {
+		t.Run(input, func(t *testing.T) {
+			v, _ := Parse(input)
+			value, err := v.Value()
+			if err != nil {
+				t.Fatalf("Value failed: %v", err)
+			}
+			if value != input {
+				t.Errorf("Value = %v, want %s", value, input)
+			}
+
+			var fromString, fromBytes Version
+			if err := fromString.Scan(value); err != nil {
+				t.Fatalf("Scan(string) failed: %v", err)
+			}
+			if err := fromBytes.Scan([]byte(input)); err != nil {
+				t.Fatalf("Scan([]byte) failed: %v", err)
+			}
+			if fromString != *v || fromBytes != *v {
+				t.Errorf("Scan = %+v, %+v, want %+v", fromString, fromBytes, *v)
+			}
+		})
+	}
+}
+
+func TestSQLScanInvalid(t *testing.T) {
+	tests := []interface{}{nil, 42, "1.2", []byte("invalid")}
+
+	for _, src := range tests {
+		var v Version
+		if err := v.Scan(src); err == nil {
+			t.Errorf("Expected error scanning %v (%T)", src, src)
+		}
+	}
+
+	var nullable sql.Null[Version]
+	if err := nullable.Scan(nil); err != nil || nullable.Valid {
+		t.Errorf("sql.Null[Version].Scan(nil) = %v, valid %v", err, nullable.Valid)
+	}
+	if err := nullable.Scan("1.2.3"); err != nil || !nullable.Valid || nullable.V.String() != "1.2.3" {
+		t.Errorf("sql.Null[Version].Scan(1.2.3) = %v, %+v", err, nullable)
+	}
+}
@@ -0,0 +1,115 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Module is a separately versioned part of a repository. Its git tags carry
+// the tag prefix in front of the version tag, e.g. api/v1.2.3 for the prefix
+// api/, like Go submodules. The zero Module stands for the repository tags v1.2.3.
+type Module struct {
+	Name      string `yaml:"name"`
+	TagPrefix string `yaml:"tag_prefix"`
+}
+
+// TagPattern returns the git glob matching the version tags of the module
+func (m Module) TagPattern() string {
+	return m.TagPrefix + "v[0-9]*"
+}
+
+// Tag returns the git tag of a version of the module (1.2.3 -> api/v1.2.3)
+func (m Module) Tag(versionStr string) string {
+	return m.TagPrefix + "v" + strings.TrimPrefix(versionStr, "v")
+}
+
+// TrimTag returns a tag of the module without the tag prefix (api/v1.2.3 -> v1.2.3),
+// false when the tag does not belong to the module
+func (m Module) TrimTag(tag string) (string, bool) {
+	versionTag, ok := strings.CutPrefix(tag, m.TagPrefix)
+	if !ok || !strings.HasPrefix(versionTag, "v") {
+		return "", false
+	}
+	return versionTag, true
+}
+
+// Module returns the module declared in project.modules by name
+func (c *ProjectConfig) Module(name string) (Module, error) {
+	for _, m := range c.Modules {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return Module{}, fmt.Errorf("unknown module '%s' (modules: %s)", name, strings.Join(c.Project.Modules, ", "))
+}
+
+// UnmarshalYAML decodes a project configuration whose project.modules entries
+// are either module names or mappings with a name and a tag prefix:
+//
+//	modules:
+//	  - web
+//	  - name: api
+//	    tag_prefix: api/
+func (c *ProjectConfig) UnmarshalYAML(node *yaml.Node) error {
+	modules, err := decodeModules(node)
+	if err != nil {
+		return err
+	}
+
+	// The plain type has no UnmarshalYAML method and decodes the fields as usual
+	type plain ProjectConfig
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+	c.Modules = modules
+	return nil
+}
+
+// decodeModules decodes the project.modules entries of a configuration node
+// and replaces mapping entries by the module name for Project.Modules
+func decodeModules(node *yaml.Node) ([]Module, error) {
+	sequence := mappingValue(mappingValue(node, "project"), "modules")
+	if sequence == nil || sequence.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	modules := make([]Module, 0, len(sequence.Content))
+	for i, item := range sequence.Content {
+		var m Module
+		if item.Kind == yaml.MappingNode {
+			if err := item.Decode(&m); err != nil {
+				return nil, fmt.Errorf("module %d: %v", i+1, err)
+			}
+			sequence.Content[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: m.Name}
+		} else if err := item.Decode(&m.Name); err != nil {
+			return nil, fmt.Errorf("module %d: %v", i+1, err)
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// mappingValue returns the value node of a key in a mapping node, nil when missing
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// validateModules checks that module tag prefixes are literal
+func validateModules(modules []Module) error {
+	for _, m := range modules {
+		if strings.ContainsAny(m.TagPrefix, "*?[\\ \t") {
+			return fmt.Errorf("module %s: tag prefix '%s' must not contain glob characters or spaces", m.Name, m.TagPrefix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModuleTags(t *testing.T) {
+	api := Module{Name: "api", TagPrefix: "api/"}
+	if api.TagPattern() != "api/v[0-9]*" {
+		t.Errorf("TagPattern() = %s, want api/v[0-9]*", api.TagPattern())
+	}
+	if api.Tag("1.2.3") != "api/v1.2.3" || api.Tag("v1.2.3") != "api/v1.2.3" {
+		t.Errorf("Tag(1.2.3) = %s, want api/v1.2.3", api.Tag("1.2.3"))
+	}
+	if (Module{}).TagPattern() != "v[0-9]*" || (Module{}).Tag("1.2.3") != "v1.2.3" {
+		t.Errorf("Zero module does not use the repository tags")
+	}
+
+	tests := []struct {
+		tag      string
+		expected string
+		ok       bool
+	}{
+		{"api/v1.2.3", "v1.2.3", true},
+		{"api/v1.3.0-rc.1", "v1.3.0-rc.1", true},
+		{"web/v1.2.3", "", false},
+		{"v1.2.3", "", false},
+		{"api/1.2.3", "", false},
+	}
+	for _, test := range tests {
+		result, ok := api.TrimTag(test.tag)
+		if result != test.expected || ok != test.ok {
+			t.Errorf("TrimTag(%s) = %s, %v, want %s, %v", test.tag, result, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestProjectConfigModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules string
+		names   []string
+		prefix  string // tag prefix of the api module
+		wantErr bool
+	}{
+		{"names", "  modules: [api, web]\n", []string{"api", "web"}, "", false},
+		{"prefixes", "  modules:\n    - web\n    - name: api\n      tag_prefix: api/\n", []string{"web", "api"}, "api/", false},
+		{"glob prefix", "  modules:\n    - name: api\n      tag_prefix: api/*\n", nil, "", true},
+		{"empty name", "  modules:\n    - tag_prefix: api/\n", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n" + tt.modules
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetProjectConfigFromFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(config.Project.Modules) != len(tt.names) || len(config.Modules) != len(tt.names) {
+				t.Fatalf("Modules = %v, %v, want %v", config.Project.Modules, config.Modules, tt.names)
+			}
+			for i, name := range tt.names {
+				if config.Project.Modules[i] != name || config.Modules[i].Name != name {
+					t.Errorf("Module[%d] = %s, want %s", i, config.Project.Modules[i], name)
+				}
+			}
+			module, err := config.Module("api")
+			if err != nil {
+				t.Fatalf("Module(api) failed: %v", err)
+			}
+			if module.TagPrefix != tt.prefix {
+				t.Errorf("Module(api).TagPrefix = %s, want %s", module.TagPrefix, tt.prefix)
+			}
+			if _, err := config.Module("unknown"); err == nil {
+				t.Errorf("Expected error for unknown module")
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError describes where and why a version string does not match the
+// extended grammar (see docs/BNF-grammar.md)
+type ParseError struct {
+	Input      string // version string that failed to parse
+	Offset     int    // byte offset of the offending character in Input
+	Expected   string // description of the expected token
+	Rule       string // grammar rule that failed (e.g. "<prerelease-type>")
+	Suggestion string // corrected version string, empty when there is no suggestion
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid version format: %s: expected %s at offset %d (%s)", e.Input, e.Expected, e.Offset, e.Rule)
+	if e.Suggestion != "" {
+		fmt.Fprintf(&b, ", did you mean %s?", e.Suggestion)
+	}
+	return b.String()
+}
+
+// prereleaseAliases maps short prerelease labels (PEP 440 style 1.2.3a1) for suggestions
+var prereleaseAliases = map[string]string{"a": "alpha", "b": "beta"}
+
+// versionParser is a single pass parser for the extended version grammar
+type versionParser struct {
+	input    string // version string without build metadata
+	pos      int
+	labels   *LabelRegistry // labels allowed by <prerelease-type> and <postrelease-type>
+	calver   *CalVer        // calendar versioning scheme of <version-core>, nil for major.minor.patch
+	segments int            // largest number of numeric segments of <version-core>
+}
+
+// parseExtended parses a version string without build metadata into v using the extended grammar.
+// A '-' prerelease delimiter (git tag format) is accepted and normalized to '~'.
+// With a calendar versioning scheme the version core follows the scheme instead of major.minor.patch.
+// Prerelease and postrelease labels and the number of numeric segments are taken from the scheme.
+func parseExtended(v *Version, versionStr string, calver *CalVer, scheme *Scheme) *ParseError {
+	p := versionParser{input: versionStr, labels: scheme.labels(), calver: calver, segments: scheme.segments()}
+	if err := p.parse(v); err != nil {
+		err.Input = versionStr
+		if calver == nil {
+			err.Suggestion = suggestVersion(versionStr, p.labels)
+		}
+		return err
+	}
+	return nil
+}
+
+// parse parses the whole input into v
+func (p *versionParser) parse(v *Version) *ParseError {
+	*v = Version{Type: TypeRelease}
+
+	if p.peek() == 'v' {
+		p.pos++
+		v.Style.Prefix = true
+	}
+
+	var err *ParseError
+	if p.calver != nil {
+		if err = p.calverCore(v); err != nil {
+			return err
+		}
+	} else {
+		if v.Major, err = p.number("major version number", "<major>"); err != nil {
+			return err
+		}
+		if err = p.expect('.', "'.' after major version", "<version-core>"); err != nil {
+			return err
+		}
+		if v.Minor, err = p.number("minor version number", "<minor>"); err != nil {
+			return err
+		}
+		if err = p.expect('.', "'.' after minor version", "<version-core>"); err != nil {
+			return err
+		}
+		if v.Patch, err = p.number("patch version number", "<patch>"); err != nil {
+			return err
+		}
+		if err = p.extraSegments(v); err != nil {
+			return err
+		}
+	}
+
+	v.Original = p.input
+	start := p.pos
+	switch p.peek() {
+	case 0:
+		// Release version
+	case '~', '-':
+		p.pos++
+		if err = p.label(TypePrerelease, "<prerelease-type>"); err != nil {
+			return err
+		}
+		if err = p.suffix("<prerelease-suffix>", false); err != nil {
+			return err
+		}
+		v.Type = TypePrerelease
+		v.Prerelease = p.input[start:]
+		if p.input[start] == '-' {
+			// Git tag format, normalize the delimiter
+			v.Prerelease = "~" + p.input[start+1:]
+			v.Style.Hyphen = true
+			v.Original = p.input[:start] + v.Prerelease
+		}
+	case '.':
+		p.pos++
+		if err = p.label(TypePostrelease, "<postrelease-type>"); err != nil {
+			return err
+		}
+		if err = p.suffix("<postrelease-suffix>", false); err != nil {
+			return err
+		}
+		v.Type = TypePostrelease
+		v.Postrelease = p.input[start:]
+	case '_':
+		p.pos++
+		if err = p.word("intermediate identifier", "<intermediate-identifier>"); err != nil {
+			return err
+		}
+		if err = p.suffix("<intermediate-suffix>", true); err != nil {
+			return err
+		}
+		v.Type = TypeIntermediate
+		v.Intermediate = p.input[start:]
+	default:
+		return p.fail("'~' prerelease, '.' postrelease or '_' intermediate delimiter", "<version>")
+	}
+
+	return nil
+}
+
+// peek returns the current byte or 0 at the end of the input
+func (p *versionParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// fail returns a parse error at the current position
+func (p *versionParser) fail(expected, rule string) *ParseError {
+	return &ParseError{Offset: p.pos, Expected: expected, Rule: rule}
+}
+
+// expect consumes a single delimiter byte
+func (p *versionParser) expect(c byte, expected, rule string) *ParseError {
+	if p.peek() != c {
+		return p.fail(expected, rule)
+	}
+	p.pos++
+	return nil
+}
+
+// number consumes a run of digits and returns its value
+func (p *versionParser) number(expected, rule string) (int, *ParseError) {
+	start := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, p.fail(expected, rule)
+	}
+	n, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.fail(expected+" that fits in an int", rule)
+	}
+	return n, nil
+}
+
+// word consumes a run of letters
+func (p *versionParser) word(expected, rule string) *ParseError {
+	start := p.pos
+	for isAlpha(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return p.fail(expected, rule)
+	}
+	return nil
+}
+
+// label consumes a word that must be a registered label of type t
+func (p *versionParser) label(t Type, rule string) *ParseError {
+	start := p.pos
+	for isAlpha(p.peek()) {
+		p.pos++
+	}
+	if label, ok := p.labels.Lookup(p.input[start:p.pos]); ok && label.Type == t {
+		return nil
+	}
+	p.pos = start
+	return p.fail(fmt.Sprintf("%s type (%s)", t, strings.Join(p.labels.names(t), ", ")), rule)
+}
+
+// suffix consumes any number of "." <numeric-identifier> and "_" <alphabetic-identifier> parts,
+// intermediate identifiers also accept "_" <numeric-identifier>
+func (p *versionParser) suffix(rule string, intermediate bool) *ParseError {
+	for {
+		switch p.peek() {
+		case 0:
+			return nil
+		case '.':
+			p.pos++
+			start := p.pos
+			for isDigit(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return p.fail("numeric identifier after '.' (use '_' before words)", rule)
+			}
+		case '_':
+			p.pos++
+			if intermediate && isDigit(p.peek()) {
+				for isDigit(p.peek()) {
+					p.pos++
+				}
+				continue
+			}
+			if err := p.word("alphabetic identifier after '_' (use '.' before numbers)", rule); err != nil {
+				return err
+			}
+		default:
+			if isAlnum(p.peek()) {
+				return p.fail("'.' or '_' between numeric and alphabetic identifiers", rule)
+			}
+			return p.fail("'.' or '_' identifier delimiter or end of version", rule)
+		}
+	}
+}
+
+// suggestVersion tries to repair common mistakes in a version string (wrong
+// delimiter, uppercase type labels, missing '.' before numbers, missing patch)
+// and returns the repaired version if it is valid, or an empty string.
+func suggestVersion(versionStr string, labels *LabelRegistry) string {
+	s := versionStr
+	var b strings.Builder
+
+	if s != "" && (s[0] == 'v' || s[0] == 'V') {
+		b.WriteByte('v')
+		s = s[1:]
+	}
+
+	// Version core, missing minor and patch versions default to 0
+	for i := 0; i < 3; i++ {
+		end := 0
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end == 0 {
+			if i == 0 {
+				return ""
+			}
+			b.WriteString(".0")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s[:end])
+		s = s[end:]
+		if i < 2 && len(s) > 1 && s[0] == '.' && isDigit(s[1]) {
+			s = s[1:]
+		}
+	}
+
+	// Split the remainder into runs of letters and digits
+	delimiter := byte(0)
+	if s != "" && strings.IndexByte("~-._", s[0]) >= 0 {
+		delimiter = s[0]
+		s = s[1:]
+	}
+	var tokens []string
+	for i := 0; i < len(s); {
+		j := i
+		switch {
+		case isAlpha(s[i]):
+			for j < len(s) && isAlpha(s[j]) {
+				j++
+			}
+		case isDigit(s[i]):
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+		default:
+			i++
+			continue
+		}
+		tokens = append(tokens, s[i:j])
+		i = j
+	}
+
+	if len(tokens) > 0 {
+		name := strings.ToLower(tokens[0])
+		label, known := labels.Lookup(name)
+		switch {
+		case delimiter == '_' && isAlpha(tokens[0][0]):
+			b.WriteString("_" + tokens[0])
+		case known && label.Type == TypePrerelease:
+			b.WriteString("~" + name)
+		case known && label.Type == TypePostrelease:
+			b.WriteString("." + name)
+		case prereleaseAliases[name] != "":
+			b.WriteString("~" + prereleaseAliases[name])
+		default:
+			return ""
+		}
+		for _, token := range tokens[1:] {
+			if isDigit(token[0]) {
+				b.WriteString("." + token)
+			} else {
+				b.WriteString("_" + token)
+			}
+		}
+	}
+
+	suggestion := b.String()
+	if suggestion == versionStr {
+		return ""
+	}
+	p := versionParser{input: suggestion, labels: labels}
+	if err := p.parse(&Version{}); err != nil {
+		return ""
+	}
+	return suggestion
+}
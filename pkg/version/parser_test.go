@@ -0,0 +1,165 @@
+package version
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input      string
+		offset     int
+		rule       string
+		suggestion string
+	}{
+		{"", 0, "<major>", ""},
+		{"invalid", 0, "<major>", ""},
+		{"V1.2.3", 0, "<major>", "v1.2.3"},
+		{"1", 1, "<version-core>", "1.0.0"},
+		{"1.2", 3, "<version-core>", "1.2.0"},
+		{"1.x.3", 2, "<minor>", ""},
+		{"1.2.3-RC1", 6, "<prerelease-type>", "1.2.3~rc.1"},
+		{"1.2.3-rc1", 8, "<prerelease-suffix>", "1.2.3~rc.1"},
+		{"1.2.3.rc.1", 6, "<postrelease-type>", "1.2.3~rc.1"},
+		{"1.2.3~fix.1", 6, "<prerelease-type>", "1.2.3.fix.1"},
+		{"1.2.3a1", 5, "<version>", "1.2.3~alpha.1"},
+		{"1.2.3.4", 6, "<postrelease-type>", ""},
+		{"1.2.3~alpha.x", 12, "<prerelease-suffix>", "1.2.3~alpha_x"},
+		{"1.2.3~alpha_1", 12, "<prerelease-suffix>", "1.2.3~alpha.1"},
+		{"1.2.3~alpha..1", 12, "<prerelease-suffix>", "1.2.3~alpha.1"},
+		{"1.2.3.fix.1a", 11, "<postrelease-suffix>", "1.2.3.fix.1_a"},
+		{"1.2.3_", 6, "<intermediate-identifier>", "1.2.3"},
+		{"1.2.3_feat2", 10, "<intermediate-suffix>", "1.2.3_feat.2"},
+		{"1.2.3_feat-1", 10, "<intermediate-suffix>", "1.2.3_feat.1"},
+		{"99999999999999999999.0.0", 0, "<major>", ""},
+		{"1.2.3-RC1+build.5", 6, "<prerelease-type>", "1.2.3~rc.1+build.5"},
+		{"  1.2.3-RC1", 6, "<prerelease-type>", "1.2.3~rc.1"},
+		{"1.2.3+", 6, "<build>", "1.2.3"},
+		{"1.2.3+a..b", 8, "<build>", "1.2.3+a.b"},
+		{"1.2.3+build.", 12, "<build>", "1.2.3+build"},
+		{"1.2.3~rc.1+.5", 11, "<build>", "1.2.3~rc.1+5"},
+		{"1.2.3+build_5", 11, "<build>", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := Parse(test.input)
+			if err == nil {
+				t.Fatalf("Expected error for input %q", test.input)
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected *ParseError for input %q, got %T: %v", test.input, err, err)
+			}
+			if parseErr.Input != strings.TrimSpace(test.input) {
+				t.Errorf("Input = %q, want %q", parseErr.Input, strings.TrimSpace(test.input))
+			}
+			if parseErr.Offset != test.offset {
+				t.Errorf("Offset = %d, want %d", parseErr.Offset, test.offset)
+			}
+			if parseErr.Rule != test.rule {
+				t.Errorf("Rule = %s, want %s", parseErr.Rule, test.rule)
+			}
+			if parseErr.Suggestion != test.suggestion {
+				t.Errorf("Suggestion = %q, want %q", parseErr.Suggestion, test.suggestion)
+			}
+			if parseErr.Expected == "" {
+				t.Errorf("Expected is empty")
+			}
+			if !strings.HasPrefix(err.Error(), "invalid version format: ") {
+				t.Errorf("Error() = %q, want invalid version format prefix", err.Error())
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := Parse("1.2.3-RC1")
+	expected := "invalid version format: 1.2.3-RC1: expected prerelease type (alpha, beta, rc, pre) at offset 6 (<prerelease-type>), did you mean 1.2.3~rc.1?"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error() = %v, want %s", err, expected)
+	}
+}
+
+func TestParsePreservesIdentifiers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		original string
+	}{
+		{"1.2.3~alpha.1.2", "~alpha.1.2", "1.2.3~alpha.1.2"},
+		{"1.2.3~alpha.1_feature", "~alpha.1_feature", "1.2.3~alpha.1_feature"},
+		{"v1.2.3-rc.1_x.2", "~rc.1_x.2", "v1.2.3~rc.1_x.2"},
+		{"1.2.3.fix.1.2", ".fix.1.2", "1.2.3.fix.1.2"},
+		{"1.2.3_dev.1_feature", "_dev.1_feature", "1.2.3_dev.1_feature"},
+		{"1.2.3_pay_123_refund.1", "_pay_123_refund.1", "1.2.3_pay_123_refund.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.input, err)
+			}
+			identifier := v.Prerelease + v.Postrelease + v.Intermediate
+			if identifier != test.expected {
+				t.Errorf("Identifier = %s, want %s", identifier, test.expected)
+			}
+			if v.Original != test.original {
+				t.Errorf("Original = %s, want %s", v.Original, test.original)
+			}
+		})
+	}
+
+	a, _ := Parse("1.2.3~alpha.1.2")
+	b, _ := Parse("1.2.3~alpha.2")
+	if Compare(a, b) >= 0 {
+		t.Errorf("Compare(1.2.3~alpha.1.2, 1.2.3~alpha.2) = %d, want < 0", Compare(a, b))
+	}
+}
+
+// legacyVersion is the regular expression language accepted before the
+// hand-written parser, the parser must accept exactly the same strings, except
+// for '_' before numbers in intermediate identifiers that branch identifiers need
+var legacyVersion = []*regexp.Regexp{
+	regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)$`),
+	regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\~(alpha|beta|rc|pre)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*)*$`),
+	regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\.(fix|next|post)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*)*$`),
+	regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\_([a-zA-Z]+)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*|\_[0-9]+)*$`),
+}
+
+func TestParseLegacyCompatibility(t *testing.T) {
+	prefixes := []string{"", "v", "V", "01.", "1.2"}
+	cores := []string{"1.2.3", "0.0.0", "01.02.03", "1.2", "1..3"}
+	delimiters := []string{"", "~", "-", ".", "_", "+", "~~"}
+	labels := []string{"", "alpha", "rc", "pre", "fix", "post", "feat", "RC", "a", "1"}
+	suffixes := []string{"", ".1", "_x", ".1.2", "_x.1", ".x", "_1", "1", ".", "_", ".1_x_y.2", "-1"}
+
+	count := 0
+	for _, prefix := range prefixes {
+		for _, core := range cores {
+			for _, delimiter := range delimiters {
+				for _, label := range labels {
+					for _, suffix := range suffixes {
+						input := prefix + core + delimiter + label + suffix
+						legacy := false
+						for _, re := range legacyVersion {
+							if re.MatchString(ConvertGitTag(input)) {
+								legacy = true
+							}
+						}
+						err := parseExtended(&Version{}, input, nil, &Scheme{})
+						if (err == nil) != legacy {
+							t.Errorf("parseExtended(%q) valid = %v, legacy grammar valid = %v", input, err == nil, legacy)
+						}
+						count++
+					}
+				}
+			}
+		}
+	}
+	t.Logf("Checked %d version strings", count)
+}
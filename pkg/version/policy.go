@@ -0,0 +1,125 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// BumpPolicy declares the project specific semantics of Bump: what a smart bump
+// does for each version type, the label of the prerelease bump, whether bumps to
+// postreleases are allowed and the first number of new identifiers. Start from
+// DefaultBumpPolicy, the zero value disallows postreleases and starts at 0.
+type BumpPolicy struct {
+	Smart       map[Type]BumpSpec // Bump of a smart bump per version type, the built-in smart bump when missing
+	Prerelease  string            // Label of the prerelease bump type, a prerelease label
+	Postrelease bool              // Allow bumps to postrelease versions
+	Start       int               // Number of new prerelease, postrelease and intermediate identifiers (alpha.1)
+}
+
+// DefaultBumpPolicy returns the built-in bump behavior: smart bumps increment
+// the patch of a release and the identifier of other versions, prerelease
+// bumps start alpha prereleases, postreleases are allowed and new identifiers
+// start at 1
+func DefaultBumpPolicy() *BumpPolicy {
+	return &BumpPolicy{Prerelease: "alpha", Postrelease: true, Start: 1}
+}
+
+// currentBumpPolicy is the policy used by Bump, nil for the default policy
+var currentBumpPolicy atomic.Pointer[BumpPolicy]
+
+// CurrentBumpPolicy returns the policy used by Bump, BumpWithSpec and BumpFromCommits
+func CurrentBumpPolicy() *BumpPolicy {
+	if p := currentBumpPolicy.Load(); p != nil {
+		return p
+	}
+	return defaultBumpPolicy
+}
+
+var defaultBumpPolicy = DefaultBumpPolicy()
+
+// SetBumpPolicy validates a policy against the current label registry and sets
+// it as the policy used by Bump. A nil policy restores the default policy.
+func SetBumpPolicy(p *BumpPolicy) error {
+	if p != nil {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	currentBumpPolicy.Store(p)
+	return nil
+}
+
+// Validate checks the policy against the current label registry: the smart
+// bumps are valid bump specs other than auto, the prerelease label is a
+// prerelease label and identifiers start at 0 or more
+func (p *BumpPolicy) Validate() error {
+	return p.validate(CurrentLabelRegistry())
+}
+
+// validate checks the policy against the labels of a registry
+func (p *BumpPolicy) validate(labels *LabelRegistry) error {
+	for t, spec := range p.Smart {
+		switch t {
+		case TypeRelease, TypePrerelease, TypePostrelease, TypeIntermediate:
+		default:
+			return fmt.Errorf("smart bump of unknown version type %s", t)
+		}
+		if err := spec.validate(labels); err != nil {
+			return fmt.Errorf("smart bump of %s versions: %v", t, err)
+		}
+		if spec.Core == BumpAuto {
+			return fmt.Errorf("smart bump of %s versions cannot be %s", t, spec)
+		}
+	}
+
+	if label, ok := labels.Lookup(p.Prerelease); !ok || label.Type != TypePrerelease {
+		return fmt.Errorf("default prerelease label '%s' is not a prerelease label (supported: %s)",
+			p.Prerelease, strings.Join(labels.Precedence(TypePrerelease), ", "))
+	}
+	if p.Start < 0 {
+		return fmt.Errorf("invalid identifier start %d: must be 0 or more", p.Start)
+	}
+	return nil
+}
+
+// String returns a one line summary of the policy,
+// e.g. "smart release=minor, prerelease alpha, postrelease allowed, start 1"
+func (p *BumpPolicy) String() string {
+	var smart []string
+	for t, spec := range p.Smart {
+		smart = append(smart, t.String()+"="+spec.String())
+	}
+	sort.Strings(smart)
+	if len(smart) == 0 {
+		smart = append(smart, "built-in")
+	}
+
+	postrelease := "allowed"
+	if !p.Postrelease {
+		postrelease = "disallowed"
+	}
+	return fmt.Sprintf("smart %s, prerelease %s, postrelease %s, start %d",
+		strings.Join(smart, " "), p.Prerelease, postrelease, p.Start)
+}
+
+// smartBump returns the bump spec the policy declares for smart bumps of a
+// version type, false for the built-in smart bump
+func (p *BumpPolicy) smartBump(t Type) (BumpSpec, bool) {
+	spec, ok := p.Smart[t]
+	if !ok || spec.Core == BumpSmart {
+		return BumpSpec{}, false
+	}
+	return spec, true
+}
+
+// prereleaseBump returns the bump type of the default prerelease label in a
+// label registry
+func (p *BumpPolicy) prereleaseBump(labels *LabelRegistry) (BumpType, error) {
+	bt, ok := labels.bumpType(p.Prerelease)
+	if !ok {
+		return BumpNone, fmt.Errorf("unknown default prerelease label '%s'", p.Prerelease)
+	}
+	return bt, nil
+}
@@ -0,0 +1,167 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// usePolicy sets a bump policy for the duration of a test
+func usePolicy(t *testing.T, p *BumpPolicy) {
+	t.Helper()
+	if err := SetBumpPolicy(p); err != nil {
+		t.Fatalf("SetBumpPolicy failed: %v", err)
+	}
+	t.Cleanup(func() { SetBumpPolicy(nil) })
+}
+
+func TestBumpPolicy(t *testing.T) {
+	policy := DefaultBumpPolicy()
+	policy.Smart = map[Type]BumpSpec{
+		TypeRelease:      {Core: BumpMinor, Label: BumpPrerelease},
+		TypePrerelease:   {Core: BumpRelease, Label: BumpNone},
+		TypeIntermediate: {Core: BumpSmart, Label: BumpNone},
+	}
+	policy.Prerelease = "beta"
+	policy.Start = 0
+	usePolicy(t, policy)
+
+	tests := []struct {
+		version  string
+		bumpType BumpType
+		expected string
+		rule     string // part of the applied rule
+	}{
+		{"1.2.3", BumpSmart, "1.3.0~beta.0", "(bump policy minor+prerelease for release versions)"},
+		{"1.3.0~beta.2", BumpSmart, "1.3.0", "finalize prerelease beta.2 to release"},
+		{"1.2.3_feat.1", BumpSmart, "1.2.3_feat.2", "increment intermediate identifier"},
+		{"1.2.3.fix.1", BumpSmart, "1.2.3.fix.2", "increment postrelease identifier"},
+		{"1.2.3", BumpPrerelease, "1.2.3~beta.0", "convert to prerelease with beta.0"},
+		{"1.2.3~alpha.3", BumpPrerelease, "1.2.3~beta.0", "promote prerelease from alpha to beta.0"},
+		{"1.2.3", BumpFix, "1.2.3.fix.0", "convert to postrelease with fix.0"},
+		{"1.2.3", BumpFeat, "1.2.3_feat.0", "convert to intermediate with feat.0"},
+		{"1.2.3+build.5", BumpSmart, "1.3.0~beta.0+build.5", "carry build metadata +build.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+"_"+test.bumpType.String(), func(t *testing.T) {
+			result, err := Bump(test.version, test.bumpType)
+			if err != nil {
+				t.Fatalf("Bump(%s, %s) failed: %v", test.version, test.bumpType, err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("Bump(%s, %s) = %s, want %s", test.version, test.bumpType, result.BumpedVersion, test.expected)
+			}
+			if !strings.Contains(result.AppliedRule, test.rule) {
+				t.Errorf("AppliedRule = %q, want it to contain %q", result.AppliedRule, test.rule)
+			}
+		})
+	}
+
+	policy = DefaultBumpPolicy()
+	policy.Postrelease = false
+	usePolicy(t, policy)
+	if _, err := Bump("1.2.3", BumpPost); err == nil {
+		t.Errorf("Expected error for a postrelease bump with postreleases disallowed")
+	}
+	if _, err := Bump("1.2.3.fix.1", BumpSmart); err == nil {
+		t.Errorf("Expected error for a smart bump of a postrelease with postreleases disallowed")
+	}
+	if result, err := Bump("1.2.3.fix.1", BumpPatch); err != nil || result.BumpedVersion != "1.2.4" {
+		t.Errorf("Bump(1.2.3.fix.1, patch) = %v, %v, want 1.2.4", result, err)
+	}
+}
+
+func TestBumpPolicyDefault(t *testing.T) {
+	if result, err := Bump("1.2.3", BumpPrerelease); err != nil || result.BumpedVersion != "1.2.3~alpha.1" {
+		t.Errorf("Bump(1.2.3, prerelease) = %v, %v, want 1.2.3~alpha.1", result, err)
+	}
+	if spec, err := ParseBumpSpec("minor+prerelease"); err != nil || spec != (BumpSpec{Core: BumpMinor, Label: BumpPrerelease}) {
+		t.Errorf("ParseBumpSpec(minor+prerelease) = %+v, %v", spec, err)
+	}
+	if CurrentBumpPolicy().String() != "smart built-in, prerelease alpha, postrelease allowed, start 1" {
+		t.Errorf("CurrentBumpPolicy() = %s", CurrentBumpPolicy())
+	}
+}
+
+func TestBumpPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy BumpPolicy
+	}{
+		{"postrelease label", BumpPolicy{Prerelease: "fix", Postrelease: true, Start: 1}},
+		{"unknown label", BumpPolicy{Prerelease: "gamma", Postrelease: true, Start: 1}},
+		{"negative start", BumpPolicy{Prerelease: "alpha", Postrelease: true, Start: -1}},
+		{"auto smart bump", BumpPolicy{Smart: map[Type]BumpSpec{TypeRelease: {Core: BumpAuto, Label: BumpNone}}, Prerelease: "alpha", Postrelease: true, Start: 1}},
+		{"invalid smart bump", BumpPolicy{Smart: map[Type]BumpSpec{TypeRelease: {Core: BumpMinor, Label: BumpFix}}, Prerelease: "alpha", Postrelease: true, Start: 1}},
+		{"invalid version type", BumpPolicy{Smart: map[Type]BumpSpec{TypeInvalid: {Core: BumpMinor, Label: BumpNone}}, Prerelease: "alpha", Postrelease: true, Start: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := SetBumpPolicy(&test.policy); err == nil {
+				SetBumpPolicy(nil)
+				t.Errorf("Expected error for %s", test.name)
+			}
+		})
+	}
+}
+
+func TestProjectConfigBumpPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		bump     string
+		expected string // policy summary
+		wantErr  bool
+	}{
+		{"empty", "", "smart built-in, prerelease alpha, postrelease allowed, start 1", false},
+		{"policy", `
+    smart:
+      release: minor
+      prerelease: release
+    prerelease: RC
+    postrelease: false
+    start: 0`, "smart prerelease=release release=minor, prerelease rc, postrelease disallowed, start 0", false},
+		{"compound smart bump", `
+    smart:
+      release: patch+beta`, "smart release=patch+beta, prerelease alpha, postrelease allowed, start 1", false},
+		{"unknown version type", `
+    smart:
+      nightly: minor`, "", true},
+		{"invalid bump spec", `
+    smart:
+      release: minor+fix`, "", true},
+		{"postrelease label", `
+    prerelease: fix`, "", true},
+		{"negative start", `
+    start: -1`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\nversion:\n  bump:" + tt.bump + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("GetProjectConfigFromFile failed: %v", err)
+			}
+			policy, err := config.BumpPolicy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BumpPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && policy.String() != tt.expected {
+				t.Errorf("BumpPolicy() = %s, want %s", policy, tt.expected)
+			}
+		})
+	}
+
+	// Without a loaded configuration the provider returns the default policy
+	if policy, err := NewConfigProvider().BumpPolicy(); err != nil || policy.String() != DefaultBumpPolicy().String() {
+		t.Errorf("ConfigProvider.BumpPolicy() = %v, %v, want the default policy", policy, err)
+	}
+}
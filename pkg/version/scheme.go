@@ -0,0 +1,119 @@
+package version
+
+// Scheme is the versioning scheme of a project: the prerelease and postrelease
+// labels, the calendar versioning format, the number of numeric segments and
+// the bump policy. Parse, Compare and Bump use the package defaults that
+// SetLabelRegistry, SetCalVer, SetSegments and SetBumpPolicy change for the
+// whole process; a Scheme passes them explicitly instead, so projects with different
+// schemes can be handled in one process. A version parsed by Scheme.Parse keeps
+// its scheme: Compare, Canonical, Diff, Convert and Bump (with BumpOptions.Scheme)
+// of it use the scheme. Zero fields are the built-in defaults, a nil *Scheme
+// stands for the package defaults.
+type Scheme struct {
+	Labels *LabelRegistry // Prerelease and postrelease labels, DefaultLabelRegistry() when nil
+	CalVer *CalVer        // Calendar versioning format, major.minor.patch versions when nil
+	// Segments is the largest number of numeric segments of N-part versions,
+	// 4 to MaxSegments (see SetSegments), major.minor.patch versions when 0
+	Segments int
+	// Policy is the bump policy, DefaultBumpPolicy() when nil. Its labels are
+	// labels of the scheme, see Validate.
+	Policy *BumpPolicy
+}
+
+// labels returns the label registry of the scheme
+func (s *Scheme) labels() *LabelRegistry {
+	switch {
+	case s == nil:
+		return CurrentLabelRegistry()
+	case s.Labels == nil:
+		return defaultLabelRegistry
+	default:
+		return s.Labels
+	}
+}
+
+// calver returns the calendar versioning format of the scheme, nil for
+// major.minor.patch versions
+func (s *Scheme) calver() *CalVer {
+	if s == nil {
+		return CurrentCalVer()
+	}
+	return s.CalVer
+}
+
+// segments returns the largest number of numeric segments of the scheme
+func (s *Scheme) segments() int {
+	switch {
+	case s == nil:
+		return CurrentSegments()
+	case s.Segments < 3:
+		return 3
+	default:
+		return min(s.Segments, MaxSegments)
+	}
+}
+
+// policy returns the bump policy of the scheme
+func (s *Scheme) policy() *BumpPolicy {
+	switch {
+	case s == nil:
+		return CurrentBumpPolicy()
+	case s.Policy == nil:
+		return defaultBumpPolicy
+	default:
+		return s.Policy
+	}
+}
+
+// Validate checks the bump policy of the scheme against its labels, like
+// SetBumpPolicy does against the current label registry
+func (s *Scheme) Validate() error {
+	return s.policy().validate(s.labels())
+}
+
+// versionScheme returns the scheme two versions are compared with, the scheme
+// of a unless only b was parsed with one
+func versionScheme(a, b *Version) *Scheme {
+	if a.scheme == nil {
+		return b.scheme
+	}
+	return a.scheme
+}
+
+// Parse parses a version string with the scheme, see the package level Parse
+func (s *Scheme) Parse(versionStr string) (*Version, error) {
+	version := &Version{}
+	if err := parseInto(version, versionStr, s.calver(), s); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// Sort sorts version strings parsed with the scheme, see the package level Sort
+func (s *Scheme) Sort(versions []string) ([]string, error) {
+	return sortVersions(versions, s.calver(), s)
+}
+
+// Extract returns the first valid version of the scheme in free text, see the
+// package level Extract
+func (s *Scheme) Extract(text string) (*Match, error) {
+	return extractVersion(text, s)
+}
+
+// FindAll returns every valid version of the scheme in free text, see the
+// package level FindAll
+func (s *Scheme) FindAll(text string) []Match {
+	return findVersions(text, -1, s)
+}
+
+// ParseBumpType parses a bump type string with the labels of the scheme,
+// see the package level ParseBumpType
+func (s *Scheme) ParseBumpType(bumpTypeStr string) (BumpType, error) {
+	return parseBumpType(bumpTypeStr, s.labels())
+}
+
+// ParseBumpSpec parses a bump spec with the labels of the scheme,
+// see the package level ParseBumpSpec
+func (s *Scheme) ParseBumpSpec(specStr string) (BumpSpec, error) {
+	return parseBumpSpec(specStr, s.labels())
+}
@@ -0,0 +1,373 @@
+package version
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newScheme returns a scheme with custom labels for the tests below
+func newScheme(t *testing.T, labels ...Label) *Scheme {
+	t.Helper()
+	registry, err := DefaultLabelRegistry().With(labels...)
+	if err != nil {
+		t.Fatalf("With failed: %v", err)
+	}
+	return &Scheme{Labels: registry}
+}
+
+func TestSchemeLabels(t *testing.T) {
+	// dev ranks below alpha in one project and above rc in the other
+	early := newScheme(t, Label{Name: "dev", Type: TypePrerelease, Rank: 50})
+	late := newScheme(t, Label{Name: "dev", Type: TypePrerelease, Rank: 500})
+
+	for _, scheme := range []*Scheme{early, late} {
+		if _, err := scheme.Parse("1.2.3~dev.1"); err != nil {
+			t.Errorf("Scheme.Parse(1.2.3~dev.1) failed: %v", err)
+		}
+	}
+	if _, err := Parse("1.2.3~dev.1"); err == nil {
+		t.Errorf("Parse(1.2.3~dev.1) accepted a label of a scheme")
+	}
+
+	compare := func(scheme *Scheme, a, b string) int {
+		t.Helper()
+		va, err := scheme.Parse(a)
+		if err != nil {
+			t.Fatalf("Scheme.Parse(%s) failed: %v", a, err)
+		}
+		vb, err := scheme.Parse(b)
+		if err != nil {
+			t.Fatalf("Scheme.Parse(%s) failed: %v", b, err)
+		}
+		return Compare(va, vb)
+	}
+	if got := compare(early, "1.2.3~dev.1", "1.2.3~alpha.1"); got >= 0 {
+		t.Errorf("Compare(1.2.3~dev.1, 1.2.3~alpha.1) = %d with dev before alpha, want < 0", got)
+	}
+	if got := compare(late, "1.2.3~dev.1", "1.2.3~rc.1"); got <= 0 {
+		t.Errorf("Compare(1.2.3~dev.1, 1.2.3~rc.1) = %d with dev after rc, want > 0", got)
+	}
+
+	// Versions parsed with the default labels are compared with the scheme of the other
+	v, err := late.Parse("1.2.3~dev.1")
+	if err != nil {
+		t.Fatalf("Scheme.Parse failed: %v", err)
+	}
+	rc, err := Parse("1.2.3~rc.1")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := Compare(rc, v); got >= 0 {
+		t.Errorf("Compare(1.2.3~rc.1, 1.2.3~dev.1) = %d with dev after rc, want < 0", got)
+	}
+
+	sorted, err := early.Sort([]string{"1.2.3~rc.1", "1.2.3~dev.1", "1.2.3~alpha.1"})
+	if err != nil {
+		t.Fatalf("Scheme.Sort failed: %v", err)
+	}
+	if got := strings.Join(sorted, ","); got != "1.2.3~dev.1,1.2.3~alpha.1,1.2.3~rc.1" {
+		t.Errorf("Scheme.Sort = %s", got)
+	}
+	sorted, err = late.Sort([]string{"1.2.3~rc.1", "1.2.3~dev.1", "1.2.3~alpha.1"})
+	if err != nil {
+		t.Fatalf("Scheme.Sort failed: %v", err)
+	}
+	if got := strings.Join(sorted, ","); got != "1.2.3~alpha.1,1.2.3~rc.1,1.2.3~dev.1" {
+		t.Errorf("Scheme.Sort = %s", got)
+	}
+
+	if _, ok := CurrentLabelRegistry().Lookup("dev"); ok {
+		t.Errorf("a Scheme changed the current label registry")
+	}
+}
+
+func TestSchemeBump(t *testing.T) {
+	scheme := newScheme(t, Label{Name: "dev", Type: TypePrerelease, Rank: 500})
+
+	if _, err := ParseBumpType("dev"); err == nil {
+		t.Errorf("ParseBumpType(dev) accepted a label of a scheme")
+	}
+	bt, err := scheme.ParseBumpType("dev")
+	if err != nil {
+		t.Fatalf("Scheme.ParseBumpType(dev) failed: %v", err)
+	}
+
+	opts := BumpOptions{Scheme: scheme}
+	tests := []struct {
+		version  string
+		bumpType BumpType
+		expected string
+	}{
+		{"1.2.3~rc.1", bt, "1.2.3~dev.1"},
+		{"1.2.3~dev.1", bt, "1.2.3~dev.2"},
+		{"1.2.3~dev.1", BumpRelease, "1.2.3"},
+	}
+	for _, test := range tests {
+		result, err := BumpWithOptions(test.version, test.bumpType, opts)
+		if err != nil {
+			t.Errorf("Bump(%s, %v) failed: %v", test.version, test.bumpType, err)
+			continue
+		}
+		if result.BumpedVersion != test.expected {
+			t.Errorf("Bump(%s, %v) = %s, want %s", test.version, test.bumpType, result.BumpedVersion, test.expected)
+		}
+	}
+
+	// dev ranks after rc, so an rc bump of a dev prerelease would go backwards
+	if _, err := BumpWithOptions("1.2.3~dev.1", BumpRc, opts); err == nil {
+		t.Errorf("Bump(1.2.3~dev.1, rc) succeeded, want an error")
+	}
+	if _, err := BumpWithOptions("1.2.3~dev.1", BumpRc, BumpOptions{}); err == nil {
+		t.Errorf("Bump(1.2.3~dev.1) without the scheme succeeded, want a parse error")
+	}
+
+	spec, err := scheme.ParseBumpSpec("minor+dev")
+	if err != nil {
+		t.Fatalf("Scheme.ParseBumpSpec(minor+dev) failed: %v", err)
+	}
+	result, err := BumpWithSpec("1.2.3", spec, opts)
+	if err != nil {
+		t.Fatalf("BumpWithSpec(1.2.3, minor+dev) failed: %v", err)
+	}
+	if result.BumpedVersion != "1.3.0~dev.1" {
+		t.Errorf("BumpWithSpec(1.2.3, minor+dev) = %s, want 1.3.0~dev.1", result.BumpedVersion)
+	}
+
+	a, _ := scheme.Parse("1.2.3~rc.1")
+	b, _ := scheme.Parse("1.2.3~dev.1")
+	if d := Diff(a, b); d.Direction != DirectionUp || !d.Successor || d.BumpType != bt {
+		t.Errorf("Diff(1.2.3~rc.1, 1.2.3~dev.1) = %s, want a dev successor", d)
+	}
+}
+
+func TestSchemeCalVer(t *testing.T) {
+	monthly, _ := ParseCalVer("YY.0M.MICRO")
+	daily, _ := ParseCalVer("YYYY.MM.DD")
+	month := &Scheme{CalVer: monthly}
+	day := &Scheme{CalVer: daily}
+
+	v, err := month.Parse("v24.05.1-rc.1")
+	if err != nil {
+		t.Fatalf("Scheme.Parse(v24.05.1-rc.1) failed: %v", err)
+	}
+	if result := v.Canonical(); result != "24.05.1~rc.1" {
+		t.Errorf("Canonical(%s) = %s, want 24.05.1~rc.1", v, result)
+	}
+	if _, err := day.Parse("2024.5.32"); err == nil {
+		t.Errorf("Scheme.Parse(2024.5.32) accepted day 32")
+	}
+	if _, err := Parse("2024.5.32"); err != nil {
+		t.Errorf("Parse(2024.5.32) used the calver of a scheme: %v", err)
+	}
+
+	may17 := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		scheme   *Scheme
+		version  string
+		expected string
+	}{
+		{month, "24.04.3", "24.05.0"},
+		{day, "2024.5.16", "2024.5.17"},
+	} {
+		result, err := BumpWithOptions(test.version, BumpCalendar, BumpOptions{Date: may17, Scheme: test.scheme})
+		if err != nil {
+			t.Errorf("Bump(%s, calendar) failed: %v", test.version, err)
+			continue
+		}
+		if result.BumpedVersion != test.expected {
+			t.Errorf("Bump(%s, calendar) = %s, want %s", test.version, result.BumpedVersion, test.expected)
+		}
+	}
+	if _, err := BumpWithOptions("24.05.3", BumpPatch, BumpOptions{Scheme: month}); err == nil {
+		t.Errorf("Bump(24.05.3, patch) with calver succeeded, want an error")
+	}
+
+	a, _ := day.Parse("2024.5.16")
+	b, _ := day.Parse("2024.5.17")
+	if d := Diff(a, b); !d.Successor {
+		t.Errorf("Diff(2024.5.16, 2024.5.17) = %s, want a successor", d)
+	}
+
+	if matches := month.FindAll("build 1.2.3 of 24.05.1"); len(matches) != 1 || matches[0].Text != "24.05.1" {
+		t.Errorf("Scheme.FindAll = %v, want 24.05.1", matches)
+	}
+	if m, err := day.Extract("nightly-2024.5.17.tgz"); err != nil || m.Version.Canonical() != "2024.5.17" {
+		t.Errorf("Scheme.Extract = %v, %v, want 2024.5.17", m, err)
+	}
+
+	if CurrentCalVer() != nil {
+		t.Errorf("a Scheme changed the current calendar versioning scheme")
+	}
+}
+
+func TestSchemeSegments(t *testing.T) {
+	windows := &Scheme{Segments: 4}
+
+	v, err := windows.Parse("1.2.3.4")
+	if err != nil {
+		t.Fatalf("Scheme.Parse(1.2.3.4) failed: %v", err)
+	}
+	if v.Extra != ".4" {
+		t.Errorf("Scheme.Parse(1.2.3.4) extra = %q, want .4", v.Extra)
+	}
+	if _, err := windows.Parse("1.2.3.4.5"); err == nil {
+		t.Errorf("Scheme.Parse(1.2.3.4.5) accepted 5 segments")
+	}
+	if _, err := Parse("1.2.3.4"); err == nil {
+		t.Errorf("Parse(1.2.3.4) used the segments of a scheme")
+	}
+	if _, err := (&Scheme{}).Parse("1.2.3.4"); err == nil {
+		t.Errorf("Scheme.Parse(1.2.3.4) accepted 4 segments without Segments")
+	}
+
+	opts := BumpOptions{Scheme: windows}
+	for _, test := range []struct {
+		version  string
+		bumpType BumpType
+		expected string
+	}{
+		{"1.2.3", BumpRevision, "1.2.3.1"},
+		{"1.2.3.4", BumpRevision, "1.2.3.5"},
+		{"1.2.3.4~rc.1", BumpRc, "1.2.3.4~rc.2"},
+	} {
+		result, err := BumpWithOptions(test.version, test.bumpType, opts)
+		if err != nil {
+			t.Errorf("Bump(%s, %v) failed: %v", test.version, test.bumpType, err)
+			continue
+		}
+		if result.BumpedVersion != test.expected {
+			t.Errorf("Bump(%s, %v) = %s, want %s", test.version, test.bumpType, result.BumpedVersion, test.expected)
+		}
+	}
+	if _, err := BumpWithOptions("1.2.3", BumpRevision, BumpOptions{Scheme: &Scheme{}}); err == nil {
+		t.Errorf("Bump(1.2.3, revision) without Segments succeeded, want an error")
+	}
+
+	a, _ := windows.Parse("1.2.3.4")
+	b, _ := windows.Parse("1.2.3.5")
+	if d := Diff(a, b); d.Change != ChangeRevision || !d.Successor || d.BumpType != BumpRevision {
+		t.Errorf("Diff(1.2.3.4, 1.2.3.5) = %s, want a revision successor", d)
+	}
+
+	if matches := windows.FindAll("setup-1.2.3.4.exe"); len(matches) != 1 || matches[0].Text != "1.2.3.4" {
+		t.Errorf("Scheme.FindAll = %v, want 1.2.3.4", matches)
+	}
+
+	if CurrentSegments() != 3 {
+		t.Errorf("a Scheme changed the current number of segments")
+	}
+}
+
+func TestSchemePolicy(t *testing.T) {
+	labels, err := DefaultLabelRegistry().With(Label{Name: "dev", Type: TypePrerelease, Rank: 50})
+	if err != nil {
+		t.Fatalf("With failed: %v", err)
+	}
+	strict := &Scheme{Labels: labels, Policy: &BumpPolicy{
+		Smart:      map[Type]BumpSpec{TypeRelease: {Core: BumpMinor, Label: BumpNone}},
+		Prerelease: "dev",
+		Start:      0,
+	}}
+	if err := strict.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	defaults := &Scheme{}
+
+	tests := []struct {
+		scheme   *Scheme
+		version  string
+		bumpType BumpType
+		expected string
+	}{
+		{strict, "1.2.3", BumpSmart, "1.3.0"},
+		{strict, "1.2.3", BumpPrerelease, "1.2.3~dev.0"},
+		{strict, "1.2.3", BumpFeat, "1.2.3_feat.0"},
+		{defaults, "1.2.3", BumpSmart, "1.2.4"},
+		{defaults, "1.2.3", BumpPrerelease, "1.2.3~alpha.1"},
+		{defaults, "1.2.3", BumpPost, "1.2.3.post.1"},
+	}
+	for _, test := range tests {
+		result, err := BumpWithOptions(test.version, test.bumpType, BumpOptions{Scheme: test.scheme})
+		if err != nil {
+			t.Errorf("Bump(%s, %v) failed: %v", test.version, test.bumpType, err)
+			continue
+		}
+		if result.BumpedVersion != test.expected {
+			t.Errorf("Bump(%s, %v) = %s, want %s", test.version, test.bumpType, result.BumpedVersion, test.expected)
+		}
+	}
+	if _, err := BumpWithOptions("1.2.3", BumpPost, BumpOptions{Scheme: strict}); err == nil {
+		t.Errorf("Bump(1.2.3, post) succeeded with postreleases disallowed")
+	}
+	result, err := BumpWithOptions("1.2.3", BumpBranch, BumpOptions{Scheme: strict, Branch: "feature/login", Tags: []string{}})
+	if err != nil {
+		t.Fatalf("Bump(1.2.3, branch) failed: %v", err)
+	}
+	if result.BumpedVersion != "1.2.3_login.0" {
+		t.Errorf("Bump(1.2.3, branch) = %s, want 1.2.3_login.0", result.BumpedVersion)
+	}
+
+	// The policy label must be a label of the scheme
+	if err := (&Scheme{Policy: strict.Policy}).Validate(); err == nil {
+		t.Errorf("Validate accepted the prerelease label dev without the dev label")
+	}
+
+	if CurrentBumpPolicy() != defaultBumpPolicy {
+		t.Errorf("a Scheme changed the current bump policy")
+	}
+}
+
+func TestProjectConfigScheme(t *testing.T) {
+	config := &ProjectConfig{}
+	config.Version.Labels = []LabelConfig{{Name: "dev", Type: "prerelease", Rank: 50}}
+	config.Version.CalVer = "YY.0M.MICRO"
+
+	scheme, err := config.Scheme()
+	if err != nil {
+		t.Fatalf("Scheme failed: %v", err)
+	}
+	if _, err := scheme.Parse("24.05.1~dev.1"); err != nil {
+		t.Errorf("Scheme.Parse(24.05.1~dev.1) failed: %v", err)
+	}
+	if _, err := scheme.Parse("1.2.3"); err == nil {
+		t.Errorf("Scheme.Parse(1.2.3) accepted a version outside the calver format")
+	}
+
+	config.Version.Labels = []LabelConfig{{Name: "rc", Type: "postrelease"}}
+	if _, err := config.Scheme(); err == nil {
+		t.Errorf("Scheme accepted a duplicate label")
+	}
+	config.Version.Labels = nil
+	config.Version.CalVer = "YY.QQ"
+	if _, err := config.Scheme(); err == nil {
+		t.Errorf("Scheme accepted an invalid calver format")
+	}
+	config.Version.CalVer = ""
+	config.Version.Segments = 4
+	if scheme, err := config.Scheme(); err != nil || scheme.Segments != 4 {
+		t.Errorf("Scheme() = %+v, %v, want 4 segments", scheme, err)
+	}
+
+	// The bump policy may use the labels of the configuration without SetLabelRegistry
+	config.Version.Segments = 0
+	config.Version.Labels = []LabelConfig{{Name: "dev", Type: "prerelease", Rank: 50}}
+	config.Version.Bump = BumpConfig{Smart: map[string]string{"release": "patch+dev"}, Prerelease: "dev"}
+	if _, err := config.BumpPolicy(); err == nil {
+		t.Errorf("BumpPolicy accepted the dev label without its label registry")
+	}
+	scheme, err = config.Scheme()
+	if err != nil {
+		t.Fatalf("Scheme failed: %v", err)
+	}
+	if scheme.Policy.Prerelease != "dev" {
+		t.Errorf("Scheme().Policy.Prerelease = %s, want dev", scheme.Policy.Prerelease)
+	}
+	result, err := BumpWithOptions("1.2.3", BumpSmart, BumpOptions{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("Bump(1.2.3, smart) failed: %v", err)
+	}
+	if result.BumpedVersion != "1.2.4~dev.1" {
+		t.Errorf("Bump(1.2.3, smart) = %s, want 1.2.4~dev.1", result.BumpedVersion)
+	}
+}
@@ -0,0 +1,91 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// MaxSegments is the largest number of numeric segments of an N-part version
+const MaxSegments = 8
+
+// currentSegments is the number of numeric segments allowed by Parse, 0 for the default of 3
+var currentSegments atomic.Int32
+
+// CurrentSegments returns the largest number of numeric segments Parse accepts,
+// 3 (major.minor.patch) unless N-part versions are enabled with SetSegments
+func CurrentSegments() int {
+	if n := currentSegments.Load(); n != 0 {
+		return int(n)
+	}
+	return 3
+}
+
+// SetSegments enables N-part numeric versions such as the Windows file version
+// 1.2.3.4: Parse accepts 3 to n numeric segments, the segments after patch are
+// stored in Version.Extra. 3 restores major.minor.patch versions.
+func SetSegments(n int) error {
+	if n < 3 || n > MaxSegments {
+		return fmt.Errorf("invalid number of version segments %d: must be 3 to %d", n, MaxSegments)
+	}
+	currentSegments.Store(int32(n))
+	return nil
+}
+
+// Segments returns the numeric segments of the version core: major, minor,
+// patch and the extra segments of an N-part version (1.2.3.4 -> [1 2 3 4])
+func (v *Version) Segments() []int {
+	segments := []int{v.Major, v.Minor, v.Patch}
+	var part string
+	for i := 0; i < len(v.Extra); {
+		part, i = nextSegment(v.Extra, i)
+		n, _ := strconv.Atoi(part)
+		segments = append(segments, n)
+	}
+	return segments
+}
+
+// nextSegment returns the numeric segment following the '.' at offset i of
+// extra segments and the offset of the next '.'
+func nextSegment(extra string, i int) (string, int) {
+	start := i + 1
+	end := start
+	for end < len(extra) && extra[end] != '.' {
+		end++
+	}
+	return extra[start:end], end
+}
+
+// compareExtra compares the extra segments of two N-part versions numerically,
+// missing segments count as 0 (1.2.3 == 1.2.3.0)
+func compareExtra(a, b string) int {
+	var aPart, bPart string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		aPart, bPart = "0", "0"
+		if i < len(a) {
+			aPart, i = nextSegment(a, i)
+		}
+		if j < len(b) {
+			bPart, j = nextSegment(b, j)
+		}
+		if result := compareNumericString(aPart, bPart); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// extraSegments parses the numeric segments after patch allowed by the scheme.
+// A '.' followed by a letter starts a postrelease identifier instead.
+func (p *versionParser) extraSegments(v *Version) *ParseError {
+	start := p.pos
+	for n := 3; n < p.segments && p.peek() == '.' && p.pos+1 < len(p.input) && isDigit(p.input[p.pos+1]); n++ {
+		p.pos++
+		if _, err := p.number("numeric version segment", "<segment>"); err != nil {
+			return err
+		}
+	}
+	v.Extra = p.input[start:p.pos]
+	return nil
+}
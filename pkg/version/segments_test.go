@@ -0,0 +1,255 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// useSegments enables N-part versions for the duration of a test
+func useSegments(t *testing.T, n int) {
+	t.Helper()
+	if err := SetSegments(n); err != nil {
+		t.Fatalf("SetSegments(%d) failed: %v", n, err)
+	}
+	t.Cleanup(func() { SetSegments(3) })
+}
+
+func TestSetSegments(t *testing.T) {
+	for _, n := range []int{0, 2, MaxSegments + 1} {
+		if err := SetSegments(n); err == nil {
+			t.Errorf("SetSegments(%d) expected error", n)
+		}
+	}
+	if CurrentSegments() != 3 {
+		t.Errorf("CurrentSegments() = %d, want 3", CurrentSegments())
+	}
+}
+
+func TestParseSegments(t *testing.T) {
+	tests := []struct {
+		segments int
+		input    string
+		expected *Version
+		wantErr  bool
+	}{
+		{3, "1.2.3.4", nil, true},
+		{4, "1.2.3.4", &Version{Major: 1, Minor: 2, Patch: 3, Extra: ".4", Type: TypeRelease, Original: "1.2.3.4"}, false},
+		{4, "v1.2.3", &Version{Major: 1, Minor: 2, Patch: 3, Type: TypeRelease, Original: "v1.2.3", Style: Style{Prefix: true}}, false},
+		{4, "1.2.3.4~rc.1", &Version{Major: 1, Minor: 2, Patch: 3, Extra: ".4", Type: TypePrerelease, Prerelease: "~rc.1", Original: "1.2.3.4~rc.1"}, false},
+		{4, "1.2.3.4.fix.1", &Version{Major: 1, Minor: 2, Patch: 3, Extra: ".4", Type: TypePostrelease, Postrelease: ".fix.1", Original: "1.2.3.4.fix.1"}, false},
+		{4, "1.2.3.fix.1", &Version{Major: 1, Minor: 2, Patch: 3, Type: TypePostrelease, Postrelease: ".fix.1", Original: "1.2.3.fix.1"}, false},
+		{4, "1.2.3.4.5", nil, true},
+		{4, "1.2.3.04", &Version{Major: 1, Minor: 2, Patch: 3, Extra: ".04", Type: TypeRelease, Original: "1.2.3.04"}, false},
+		{5, "1.2.3.4.5", &Version{Major: 1, Minor: 2, Patch: 3, Extra: ".4.5", Type: TypeRelease, Original: "1.2.3.4.5"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.segments)+"_"+test.input, func(t *testing.T) {
+			useSegments(t, test.segments)
+			result, err := Parse(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Parse(%s) expected error, got %+v", test.input, *result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.input, err)
+			}
+			// Parse caches the identifier tokens the expected literal lacks
+			expected := *test.expected
+			expected.tokens = tokenize(&expected)
+			if *result != expected {
+				t.Errorf("Parse(%s) = %+v, want %+v", test.input, *result, expected)
+			}
+			composed := *result
+			composed.Original = ""
+			if composed.String() != strings.TrimPrefix(test.input, "v") {
+				t.Errorf("String() = %s, want %s", composed.String(), strings.TrimPrefix(test.input, "v"))
+			}
+		})
+	}
+}
+
+func TestCompareSegments(t *testing.T) {
+	useSegments(t, 5)
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3.0", 0},
+		{"1.2.3.0.0", "1.2.3", 0},
+		{"1.2.3", "1.2.3.1", -1},
+		{"1.2.3.10", "1.2.3.9", 1},
+		{"1.2.3.4.1", "1.2.3.5", -1},
+		{"1.2.3.4", "1.2.4", -1},
+		{"1.2.3.4~rc.1", "1.2.3.4", -1},
+		{"1.2.3.fix.1", "1.2.3.1", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_"+test.b, func(t *testing.T) {
+			a, err := Parse(test.a)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.a, err)
+			}
+			b, err := Parse(test.b)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.b, err)
+			}
+			if result := Compare(a, b); result != test.expected {
+				t.Errorf("Compare(%s, %s) = %d, want %d", test.a, test.b, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestSegments(t *testing.T) {
+	useSegments(t, 5)
+
+	v, _ := Parse("1.2.3.4.5")
+	if got := v.Segments(); len(got) != 5 || got[3] != 4 || got[4] != 5 {
+		t.Errorf("Segments() = %v, want [1 2 3 4 5]", got)
+	}
+}
+
+func TestBumpSegments(t *testing.T) {
+	tests := []struct {
+		input    string
+		bumpType BumpType
+		expected string
+	}{
+		{"1.2.3", BumpRevision, "1.2.3.1"},
+		{"1.2.3.4", BumpRevision, "1.2.3.5"},
+		{"1.2.3.4.5", BumpRevision, "1.2.3.5"},
+		{"1.2.3.4~rc.1", BumpRevision, "1.2.3.5"},
+		{"1.2.3", BumpSmart, "1.2.4"},
+		{"1.2.3.4", BumpSmart, "1.2.3.5"},
+		{"1.2.3.4.5", BumpSmart, "1.2.3.4.6"},
+		{"1.2.3.4", BumpPatch, "1.2.4"},
+		{"1.2.3.4", BumpMajor, "2.0.0"},
+		{"1.2.3.4", BumpRc, "1.2.3.4~rc.1"},
+		{"1.2.3.4~rc.1", BumpSmart, "1.2.3.4~rc.2"},
+		{"1.2.3.4", BumpFix, "1.2.3.4.fix.1"},
+		{"1.2.3.4+build.7", BumpSmart, "1.2.3.5+build.7"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"_"+test.bumpType.String(), func(t *testing.T) {
+			useSegments(t, 5)
+			result, err := Bump(test.input, test.bumpType)
+			if err != nil {
+				t.Fatalf("Bump failed: %v", err)
+			}
+			if result.BumpedVersion != test.expected {
+				t.Errorf("Bump(%s, %s) = %s, want %s", test.input, test.bumpType, result.BumpedVersion, test.expected)
+			}
+		})
+	}
+
+	if _, err := Bump("1.2.3", BumpRevision); err == nil {
+		t.Errorf("Expected error for revision bump of major.minor.patch versions")
+	}
+	if bt, err := ParseBumpType("build"); err != nil || bt != BumpRevision {
+		t.Errorf("ParseBumpType(build) = %v, %v, want revision", bt, err)
+	}
+}
+
+func TestDiffSegments(t *testing.T) {
+	useSegments(t, 4)
+
+	a, _ := Parse("1.2.3.4")
+	b, _ := Parse("1.2.3.5")
+	result := Diff(a, b)
+	if result.Change != ChangeRevision || result.Delta != 1 || !result.Successor || result.BumpType != BumpRevision {
+		t.Errorf("Diff(%s, %s) = %s", a, b, result)
+	}
+}
+
+func TestConvertSegments(t *testing.T) {
+	tests := []struct {
+		input    string
+		target   Target
+		expected string
+		hasError bool
+	}{
+		{"1.2.3.4", TargetWindows, "1,2,3,4", false},
+		{"1.2.3", TargetWindows, "1,2,3,0", false},
+		{"1.2.3.4+build.5", TargetWindows, "1,2,3,4", false},
+		{"1.2.65535.4", TargetWindows, "1,2,65535,4", false},
+		{"1.2.65536", TargetWindows, "", true},
+		{"1.2.3.4.5", TargetWindows, "", true},
+		{"1.2.3.4~rc.1", TargetWindows, "", true},
+		{"1.2.3.4~rc.1", TargetPEP440, "1.2.3.4rc1", false},
+		{"1.2.3.4", TargetDeb, "", true},
+		{"1.2.3.4", TargetNPM, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"_"+test.target.String(), func(t *testing.T) {
+			useSegments(t, 5)
+			v, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.input, err)
+			}
+			result, err := Convert(v, test.target)
+			if (err != nil) != test.hasError {
+				t.Fatalf("Convert(%s, %s) error = %v, hasError %v", test.input, test.target, err, test.hasError)
+			}
+			if result != test.expected {
+				t.Errorf("Convert(%s, %s) = %s, want %s", test.input, test.target, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestConvertOrderWindows(t *testing.T) {
+	assertConvertOrder(t, TargetWindows, func(a, b string) (int, error) {
+		aParts, bParts := strings.Split(a, ","), strings.Split(b, ",")
+		for i := range aParts {
+			if result := compareNumericString(aParts[i], bParts[i]); result != 0 {
+				return result, nil
+			}
+		}
+		return 0, nil
+	})
+}
+
+func TestProjectConfigSegments(t *testing.T) {
+	tests := []struct {
+		section  string
+		expected int
+		wantErr  bool
+	}{
+		{"version:\n  segments: 4\n", 4, false},
+		{"version:\n  segments: 9\n", 0, true},
+		{"version:\n  segments: 4\n  calver: YYYY.MM.DD\n", 0, true},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".project.yml")
+			content := "project:\n  name: test\n  modules: [test]\n" + tt.section
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := GetProjectConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("GetProjectConfigFromFile failed: %v", err)
+			}
+			n, err := config.Segments()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Segments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n != tt.expected {
+				t.Errorf("Segments() = %d, want %d", n, tt.expected)
+			}
+		})
+	}
+}
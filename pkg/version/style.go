@@ -0,0 +1,74 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Style describes how a version string is written. Parse records the style of
+// its input in Version.Style, so a version can be rendered the way it came in.
+type Style struct {
+	Prefix bool // 'v' prefix (v1.2.3)
+	Hyphen bool // '-' prerelease delimiter of git tags (1.2.3-rc.1) instead of '~'
+}
+
+var (
+	// StyleCanonical writes versions without prefix and with the '~' prerelease delimiter (1.2.3~rc.1)
+	StyleCanonical = Style{}
+	// StyleGit writes versions as git tags (v1.2.3-rc.1), the inverse of ConvertGitTag
+	StyleGit = Style{Prefix: true, Hyphen: true}
+)
+
+func (s Style) String() string {
+	switch s {
+	case StyleCanonical:
+		return "canonical"
+	case StyleGit:
+		return "git"
+	case Style{Prefix: true}:
+		return "prefix"
+	default:
+		return "hyphen"
+	}
+}
+
+// ParseStyle parses a style name: canonical (1.2.3~rc.1), git (v1.2.3-rc.1),
+// prefix (v1.2.3~rc.1) or hyphen (1.2.3-rc.1)
+func ParseStyle(styleStr string) (Style, error) {
+	switch strings.ToLower(strings.TrimSpace(styleStr)) {
+	case "canonical", "":
+		return StyleCanonical, nil
+	case "git", "tag":
+		return StyleGit, nil
+	case "prefix":
+		return Style{Prefix: true}, nil
+	case "hyphen":
+		return Style{Hyphen: true}, nil
+	default:
+		return StyleCanonical, fmt.Errorf("unknown style: %s (supported: canonical, git, prefix, hyphen)", styleStr)
+	}
+}
+
+// Format returns the version written in the given style. Unlike Canonical the
+// identifiers and build metadata are kept as they are, Format(v.Style) returns
+// the version as it was parsed (v1.2.3-rc.1 stays v1.2.3-rc.1).
+// Prerelease identifiers of DialectSemVer2 versions always use '-'.
+func (v *Version) Format(style Style) string {
+	s := strings.TrimPrefix(v.String(), "v")
+	if style.Hyphen && v.Type == TypePrerelease && v.Dialect != DialectSemVer2 {
+		// The prerelease identifier is followed only by build metadata
+		if i := len(s) - len(v.Build) - len(v.Prerelease); i >= 0 && s[i] == '~' {
+			s = s[:i] + "-" + s[i+1:]
+		}
+	}
+	if style.Prefix {
+		return "v" + s
+	}
+	return s
+}
+
+// ToGitTag returns the git tag of the version (1.2.3~rc.1 -> v1.2.3-rc.1),
+// the inverse of ConvertGitTag
+func (v *Version) ToGitTag() string {
+	return v.Format(StyleGit)
+}
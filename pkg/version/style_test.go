@@ -0,0 +1,92 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input     string
+		style     Style
+		canonical string
+		git       string
+	}{
+		{"1.2.3", StyleCanonical, "1.2.3", "v1.2.3"},
+		{"v1.2.3", Style{Prefix: true}, "1.2.3", "v1.2.3"},
+		{"v1.2.3-rc.1", StyleGit, "1.2.3~rc.1", "v1.2.3-rc.1"},
+		{"1.2.3-rc.01+build.5", Style{Hyphen: true}, "1.2.3~rc.01+build.5", "v1.2.3-rc.01+build.5"},
+		{"v1.2.3~alpha.1", Style{Prefix: true}, "1.2.3~alpha.1", "v1.2.3-alpha.1"},
+		{"1.2.3.fix.1", StyleCanonical, "1.2.3.fix.1", "v1.2.3.fix.1"},
+		{"1.2.3_feat.1", StyleCanonical, "1.2.3_feat.1", "v1.2.3_feat.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", test.input, err)
+			}
+			if v.Style != test.style {
+				t.Errorf("Parse(%s).Style = %s, want %s", test.input, v.Style, test.style)
+			}
+			if result := v.Format(v.Style); result != test.input {
+				t.Errorf("Format(%s) = %s, want the input", test.input, result)
+			}
+			if result := v.Format(StyleCanonical); result != test.canonical {
+				t.Errorf("Format(%s, canonical) = %s, want %s", test.input, result, test.canonical)
+			}
+			if result := v.ToGitTag(); result != test.git {
+				t.Errorf("ToGitTag(%s) = %s, want %s", test.input, result, test.git)
+			}
+			if ConvertGitTag(v.ToGitTag()) != "v"+test.canonical {
+				t.Errorf("ConvertGitTag(%s) = %s, want v%s", v.ToGitTag(), ConvertGitTag(v.ToGitTag()), test.canonical)
+			}
+		})
+	}
+
+	s, _ := ParseWithOptions("1.2.3-rc.1", DialectSemVer2)
+	if result := s.Format(StyleCanonical); result != "1.2.3-rc.1" {
+		t.Errorf("Format(semver2 1.2.3-rc.1, canonical) = %s, want 1.2.3-rc.1", result)
+	}
+}
+
+func TestBumpStyle(t *testing.T) {
+	tests := []struct {
+		input    string
+		bumpType BumpType
+		expected string // bumped version in the style of the input
+	}{
+		{"v1.2.3-rc.1", BumpSmart, "v1.2.3-rc.2"},
+		{"v1.2.3", BumpMinor, "v1.3.0"},
+		{"1.2.3-alpha.1", BumpSmart, "1.2.3-alpha.2"},
+		{"v1.2.3", BumpRc, "v1.2.3~rc.1"},
+		{"v1.2.3-rc.1+build.5", BumpPatch, "v1.2.4+build.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"_"+test.bumpType.String(), func(t *testing.T) {
+			result, err := Bump(test.input, test.bumpType)
+			if err != nil {
+				t.Fatalf("Bump(%s) failed: %v", test.input, err)
+			}
+			if formatted := result.Version.Format(result.Version.Style); formatted != test.expected {
+				t.Errorf("Bump(%s, %s) in input style = %s, want %s", test.input, test.bumpType, formatted, test.expected)
+			}
+			if result.BumpedVersion != ConvertGitTag(result.Version.Format(StyleCanonical)) {
+				t.Errorf("BumpedVersion = %s, want canonical style", result.BumpedVersion)
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for name, expected := range map[string]Style{"canonical": StyleCanonical, "GIT": StyleGit, "prefix": {Prefix: true}, "hyphen": {Hyphen: true}} {
+		style, err := ParseStyle(name)
+		if err != nil || style != expected {
+			t.Errorf("ParseStyle(%s) = %s, %v, want %s", name, style, err, expected)
+		}
+	}
+	if _, err := ParseStyle("debian"); err == nil {
+		t.Errorf("Expected error for unknown style")
+	}
+}
@@ -0,0 +1,334 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompareDeb compares two Debian package versions ([epoch:]upstream[-revision])
+// the way `dpkg --compare-versions` does.
+// Returns -1 if a < b, 0 if a == b, 1 if a > b.
+func CompareDeb(a, b string) (int, error) {
+	va, err := parseDebVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseDebVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if result := compareNumericString(va.epoch, vb.epoch); result != 0 {
+		return result, nil
+	}
+	if result := debVerRevCmp(va.upstream, vb.upstream); result != 0 {
+		return sign(result), nil
+	}
+	return sign(debVerRevCmp(va.revision, vb.revision)), nil
+}
+
+// CompareRPM compares two RPM versions ([epoch:]version[-release]) the way
+// rpm does with rpmvercmp. The release is only compared when both versions have one.
+// Returns -1 if a < b, 0 if a == b, 1 if a > b.
+func CompareRPM(a, b string) (int, error) {
+	va, err := parseRPMVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseRPMVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if result := compareNumericString(va.epoch, vb.epoch); result != 0 {
+		return result, nil
+	}
+	if result := rpmVerCmp(va.upstream, vb.upstream); result != 0 {
+		return result, nil
+	}
+	if va.revision == "" || vb.revision == "" {
+		return 0, nil
+	}
+	return rpmVerCmp(va.revision, vb.revision), nil
+}
+
+// foreignVersion is an epoch:version-revision triple of a package manager version
+type foreignVersion struct {
+	epoch    string
+	upstream string
+	revision string
+}
+
+// splitEpoch splits a version into its epoch ("0" when missing) and the remainder
+func splitEpoch(versionStr string) (string, string, error) {
+	i := strings.IndexByte(versionStr, ':')
+	if i < 0 {
+		return "0", versionStr, nil
+	}
+	epoch := versionStr[:i]
+	if epoch == "" {
+		return "", "", fmt.Errorf("invalid version format: %s: epoch is empty", versionStr)
+	}
+	if !isNumericIdentifier(epoch) {
+		return "", "", fmt.Errorf("invalid version format: %s: epoch is not a number", versionStr)
+	}
+	if versionStr[i+1:] == "" {
+		return "", "", fmt.Errorf("invalid version format: %s: nothing after colon", versionStr)
+	}
+	return epoch, versionStr[i+1:], nil
+}
+
+// parseDebVersion parses and validates a Debian version
+func parseDebVersion(versionStr string) (*foreignVersion, error) {
+	versionStr = strings.TrimSpace(versionStr)
+	if versionStr == "" {
+		return nil, fmt.Errorf("invalid version format: version string is empty")
+	}
+
+	epoch, rest, err := splitEpoch(versionStr)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &foreignVersion{epoch: epoch, upstream: rest}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.upstream, v.revision = rest[:i], rest[i+1:]
+		if v.revision == "" {
+			return nil, fmt.Errorf("invalid version format: %s: revision is empty", versionStr)
+		}
+	}
+
+	if v.upstream == "" || !isDigit(v.upstream[0]) {
+		return nil, fmt.Errorf("invalid version format: %s: version number does not start with digit", versionStr)
+	}
+	for i := 0; i < len(v.upstream); i++ {
+		if c := v.upstream[i]; !isAlnum(c) && !strings.ContainsRune(".-+~:", rune(c)) {
+			return nil, fmt.Errorf("invalid version format: %s: invalid character in version number", versionStr)
+		}
+	}
+	for i := 0; i < len(v.revision); i++ {
+		if c := v.revision[i]; !isAlnum(c) && !strings.ContainsRune(".+~", rune(c)) {
+			return nil, fmt.Errorf("invalid version format: %s: invalid character in revision number", versionStr)
+		}
+	}
+	return v, nil
+}
+
+// parseRPMVersion parses an RPM epoch:version-release string
+func parseRPMVersion(versionStr string) (*foreignVersion, error) {
+	versionStr = strings.TrimSpace(versionStr)
+	if versionStr == "" {
+		return nil, fmt.Errorf("invalid version format: version string is empty")
+	}
+
+	epoch, rest, err := splitEpoch(versionStr)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &foreignVersion{epoch: epoch, upstream: rest}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.upstream, v.revision = rest[:i], rest[i+1:]
+		if v.upstream == "" || v.revision == "" {
+			return nil, fmt.Errorf("invalid version format: %s: empty version or release", versionStr)
+		}
+	}
+	return v, nil
+}
+
+// debOrder returns the sort weight of a character in dpkg's non-digit comparison:
+// '~' sorts before the end of the string, letters before all other characters
+func debOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+// debVerRevCmp is the dpkg verrevcmp algorithm comparing alternating
+// non-digit and digit parts of two upstream versions or revisions
+func debVerRevCmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		firstDiff := 0
+
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac := debOrder(a, i)
+			bc := debOrder(b, j)
+			if ac != bc {
+				return ac - bc
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		for i < len(a) && j < len(b) && isDigit(a[i]) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// rpmVerCmp is the rpmvercmp algorithm comparing alphabetic and numeric
+// segments of two versions or releases, with '~' sorting before and '^'
+// sorting after the end of the string
+func rpmVerCmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+
+		// Tilde sorts before everything else
+		if (i < len(a) && a[i] == '~') || (j < len(b) && b[j] == '~') {
+			if i >= len(a) || a[i] != '~' {
+				return 1
+			}
+			if j >= len(b) || b[j] != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		// Caret sorts after the end of the string but before anything else
+		if (i < len(a) && a[i] == '^') || (j < len(b) && b[j] == '^') {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if a[i] != '^' {
+				return 1
+			}
+			if b[j] != '^' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		isNum := isDigit(a[i])
+		ei, ej := i, j
+		if isNum {
+			for ei < len(a) && isDigit(a[ei]) {
+				ei++
+			}
+			for ej < len(b) && isDigit(b[ej]) {
+				ej++
+			}
+		} else {
+			for ei < len(a) && isAlpha(a[ei]) {
+				ei++
+			}
+			for ej < len(b) && isAlpha(b[ej]) {
+				ej++
+			}
+		}
+
+		// Segments of different types: numeric segments are newer than alphabetic ones
+		if ej == j {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		var result int
+		if isNum {
+			result = compareNumericString(a[i:ei], b[j:ej])
+		} else {
+			result = strings.Compare(a[i:ei], b[j:ej])
+		}
+		if result != 0 {
+			return result
+		}
+		i, j = ei, ej
+	}
+
+	if i >= len(a) && j >= len(b) {
+		return 0
+	}
+	if i >= len(a) {
+		return -1
+	}
+	return 1
+}
+
+// compareNumericString compares two digit strings of any length numerically
+func compareNumericString(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return sign(len(a) - len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+// sign normalizes a comparison result to -1, 0 or 1
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || isAlpha(c)
+}
@@ -0,0 +1,138 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestCompareDeb(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.1", "1.0", 1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0~~a", "1.0~~", 1},
+		{"1.0~", "1.0", -1},
+		{"1.0", "1.0+", -1},
+		{"1.0a", "1.0+", -1},
+		{"1.0.1", "1.0a", 1},
+		{"1.001", "1.1", 0},
+		{"1.0+fix.1", "1.0.feat", -1},
+		{"1:1.0", "2.0", 1},
+		{"0:1.0", "1.0", 0},
+		{"10:1.0", "9:2.0", 1},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1", "1.0", 1},
+		{"1.0-0", "1.0", 0},
+		{"2.5-1", "2.5-1ubuntu1", -1},
+		{"2.5-1ubuntu1", "2.5-1ubuntu1.1", -1},
+		{"7.6p2-4", "7.6-0", 1},
+		{"1.2-3-4", "1.2-3-5", -1},
+		{"1:2:3", "1:2:4", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_vs_"+test.b, func(t *testing.T) {
+			result, err := CompareDeb(test.a, test.b)
+			if err != nil {
+				t.Fatalf("CompareDeb(%s, %s) failed: %v", test.a, test.b, err)
+			}
+			if result != test.expected {
+				t.Errorf("CompareDeb(%s, %s) = %d, want %d", test.a, test.b, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestCompareDebErrors(t *testing.T) {
+	for _, input := range []string{"", "a1.0", "1:", ":1.0", "x:1.0", "1.0-", "1.0_1", "1.0-1_2"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := CompareDeb(input, "1.0"); err == nil {
+				t.Errorf("Expected error for deb version %q", input)
+			}
+		})
+	}
+}
+
+func TestCompareRPM(t *testing.T) {
+	// Cases from the rpm test suite (rpmvercmp.at)
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0", "1.0", 1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1a", "2.0.1", 1},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p10", "5.5p1", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz.4", "8", -1},
+		{"xyz.4", "2", -1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.0.rc1", "6.0", 1},
+		{"10b2", "10a1", 1},
+		{"1.0aa", "1.0a", 1},
+		{"10.0001", "10.1", 0},
+		{"10.0001", "10.0039", -1},
+		{"4.999.9", "5.0", -1},
+		{"20101121", "20101122", -1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"a", "a", 0},
+		{"a+", "a_", 0},
+		{"+a", "_a", 0},
+		{"+_", "_+", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0^", "1.0", 1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0^git2", -1},
+		{"1.0^git1", "1.01", -1},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0^20160101^git1", "1.0^20160101", 1},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
+		{"99999999999999999999", "100000000000000000000", -1},
+
+		// Epoch and release
+		{"1:1.0", "2.0", 1},
+		{"0:1.0-1", "1.0-1", 0},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1", "1.0", 0},
+		{"1.0-1.el9", "1.0-1.el10", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_vs_"+test.b, func(t *testing.T) {
+			result, err := CompareRPM(test.a, test.b)
+			if err != nil {
+				t.Fatalf("CompareRPM(%s, %s) failed: %v", test.a, test.b, err)
+			}
+			if result != test.expected {
+				t.Errorf("CompareRPM(%s, %s) = %d, want %d", test.a, test.b, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestCompareRPMErrors(t *testing.T) {
+	for _, input := range []string{"", "1:", ":1.0", "x:1.0", "1.0-", "-1"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := CompareRPM(input, "1.0"); err == nil {
+				t.Errorf("Expected error for rpm version %q", input)
+			}
+		})
+	}
+}
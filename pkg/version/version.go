@@ -6,8 +6,7 @@ package version
 import (
 	"fmt"
 	"regexp"
-	"sort"
-	"strconv"
+	"slices"
 	"strings"
 )
 
@@ -45,25 +44,43 @@ func (t Type) BuildType() string {
 	return "Debug"
 }
 
+// ParseType parses a version type name (release, prerelease, postrelease, intermediate)
+func ParseType(typeStr string) (Type, error) {
+	switch strings.ToLower(strings.TrimSpace(typeStr)) {
+	case "release":
+		return TypeRelease, nil
+	case "prerelease":
+		return TypePrerelease, nil
+	case "postrelease":
+		return TypePostrelease, nil
+	case "intermediate":
+		return TypeIntermediate, nil
+	default:
+		return TypeInvalid, fmt.Errorf("unknown version type: %s", typeStr)
+	}
+}
+
 // Version represents a parsed version with all its components
 type Version struct {
 	Major       int    // Major version number
 	Minor       int    // Minor version number
 	Patch       int    // Patch version number
+	Extra       string // Numeric segments after patch of an N-part version (e.g., ".4" in 1.2.3.4)
 	Type        Type   // Version type (release, prerelease, etc.)
 	Prerelease  string // Prerelease identifier (e.g., "~alpha.1")
 	Postrelease string // Postrelease identifier (e.g., ".fix.1")
 	Intermediate string // Intermediate identifier (e.g., "_feature.1")
+	Build       string // Build metadata (e.g., "+build.42"), ignored for precedence
 	Original    string // Original version string
+	Dialect     Dialect // Grammar dialect the version was parsed with
+	Style       Style   // How the version was written ('v' prefix, '-' prerelease delimiter)
+
+	tokens identifierTokens // type identifier split by Parse, used by Compare
+	scheme *Scheme          // scheme the version was parsed with, nil for the package defaults
 }
 
-// Regex patterns for version parsing
-var (
-	versionRelease      = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)$`)
-	versionPrerelease   = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\~(alpha|beta|rc|pre)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*)*$`)
-	versionPostrelease  = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\.(fix|next|post)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*)*$`)
-	versionIntermediate = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)\_([a-zA-Z]+)(\.[0-9]+|\_[a-zA-Z]+(\.[0-9]+)*)*$`)
-)
+// gitTagPrerelease matches a git tag with a '-' prerelease delimiter
+var gitTagPrerelease = regexp.MustCompile(`^(v?[0-9]+\.[0-9]+\.[0-9]+)-(.+)$`)
 
 // ConvertGitTag converts git tag format from x.y.z-(remainder) to x.y.z~(remainder)
 // This is useful for handling git tags that use - delimiter instead of ~ for prerelease versions
@@ -72,8 +89,7 @@ func ConvertGitTag(tag string) string {
 	if strings.Contains(tag, "-") && !strings.Contains(tag, "~") {
 		// Find the first occurrence of - after the version number
 		// Pattern: v?x.y.z-remainder
-		re := regexp.MustCompile(`^(v?[0-9]+\.[0-9]+\.[0-9]+)-(.+)$`)
-		if matches := re.FindStringSubmatch(tag); matches != nil {
+		if matches := gitTagPrerelease.FindStringSubmatch(tag); matches != nil {
 			return matches[1] + "~" + matches[2]
 		}
 	}
@@ -83,78 +99,104 @@ func ConvertGitTag(tag string) string {
 // Parse parses a version string and returns a Version struct
 // It supports release, prerelease, postrelease, and intermediate version formats
 func Parse(versionStr string) (*Version, error) {
+	version := &Version{}
+	if err := parseInto(version, versionStr, CurrentCalVer(), nil); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// parseInto parses a version string into an existing Version, with a calendar
+// versioning core when calver is not nil and the labels and segments of scheme.
+// Identifiers and Original refer to the input string, so parsing a canonical
+// version (no '-' git tag delimiter) does not allocate.
+func parseInto(version *Version, versionStr string, calver *CalVer, scheme *Scheme) error {
 	versionStr = strings.TrimSpace(versionStr)
 	
-	// Convert git tag format if needed
-	versionStr = ConvertGitTag(versionStr)
-	
-	// Try release version first
-	if matches := versionRelease.FindStringSubmatch(versionStr); matches != nil {
-		major, _ := strconv.Atoi(matches[1])
-		minor, _ := strconv.Atoi(matches[2])
-		patch, _ := strconv.Atoi(matches[3])
-		
-		return &Version{
-			Major:    major,
-			Minor:    minor,
-			Patch:    patch,
-			Type:     TypeRelease,
-			Original: versionStr,
-		}, nil
+	// Split off build metadata, it never takes part in the version grammar
+	core, build, err := splitBuild(versionStr)
+	if err != nil {
+		return err
 	}
 	
-	// Try prerelease version
-	if matches := versionPrerelease.FindStringSubmatch(versionStr); matches != nil {
-		major, _ := strconv.Atoi(matches[1])
-		minor, _ := strconv.Atoi(matches[2])
-		patch, _ := strconv.Atoi(matches[3])
-		prerelease := "~" + matches[4] + matches[5] + matches[6]
-		
-		return &Version{
-			Major:      major,
-			Minor:      minor,
-			Patch:      patch,
-			Type:       TypePrerelease,
-			Prerelease: prerelease,
-			Original:   versionStr,
-		}, nil
+	// Git tag format (x.y.z-remainder) is accepted by the parser directly
+	if parseErr := parseExtended(version, core, calver, scheme); parseErr != nil {
+		parseErr.Input = versionStr
+		if parseErr.Suggestion != "" {
+			parseErr.Suggestion += build
+		}
+		return parseErr
 	}
-	
-	// Try postrelease version
-	if matches := versionPostrelease.FindStringSubmatch(versionStr); matches != nil {
-		major, _ := strconv.Atoi(matches[1])
-		minor, _ := strconv.Atoi(matches[2])
-		patch, _ := strconv.Atoi(matches[3])
-		postrelease := "." + matches[4] + matches[5]
-		
-		return &Version{
-			Major:       major,
-			Minor:       minor,
-			Patch:       patch,
-			Type:        TypePostrelease,
-			Postrelease: postrelease,
-			Original:    versionStr,
-		}, nil
+	if build != "" {
+		version.Build = build
+		if version.Original == core {
+			version.Original = versionStr
+		} else {
+			version.Original += build
+		}
 	}
-	
-	// Try intermediate version
-	if matches := versionIntermediate.FindStringSubmatch(versionStr); matches != nil {
-		major, _ := strconv.Atoi(matches[1])
-		minor, _ := strconv.Atoi(matches[2])
-		patch, _ := strconv.Atoi(matches[3])
-		intermediate := "_" + matches[4] + matches[5]
-		
-		return &Version{
-			Major:        major,
-			Minor:        minor,
-			Patch:        patch,
-			Type:         TypeIntermediate,
-			Intermediate: intermediate,
-			Original:     versionStr,
-		}, nil
+	version.tokens = tokenize(version)
+	version.scheme = scheme
+	return nil
+}
+
+// splitBuild splits a version string into the version part and its build metadata.
+// The returned build metadata keeps its '+' delimiter.
+func splitBuild(versionStr string) (string, string, error) {
+	i := strings.IndexByte(versionStr, '+')
+	if i < 0 {
+		return versionStr, "", nil
 	}
-	
-	return nil, fmt.Errorf("invalid version format: %s", versionStr)
+	if parseErr := parseBuild(versionStr, i+1); parseErr != nil {
+		return "", "", parseErr
+	}
+	return versionStr[:i], versionStr[i:], nil
+}
+
+// parseBuild checks that the build metadata starting at offset in versionStr is a
+// dot separated list of non-empty identifiers of alphanumerics and hyphens.
+// An empty identifier (1.2.3+a..b) is reported with a suggestion that drops it.
+func parseBuild(versionStr string, offset int) *ParseError {
+	start := offset
+	for i := offset; i <= len(versionStr); i++ {
+		if i == len(versionStr) || versionStr[i] == '.' {
+			if i == start {
+				return &ParseError{
+					Input:      versionStr,
+					Offset:     i,
+					Expected:   "build identifier (letters, digits, '-')",
+					Rule:       "<build>",
+					Suggestion: dropEmptyBuildIdentifiers(versionStr, offset),
+				}
+			}
+			start = i + 1
+			continue
+		}
+		if !isAlnum(versionStr[i]) && versionStr[i] != '-' {
+			return &ParseError{
+				Input:    versionStr,
+				Offset:   i,
+				Expected: "build identifier character (letters, digits, '-')",
+				Rule:     "<build>",
+			}
+		}
+	}
+	return nil
+}
+
+// dropEmptyBuildIdentifiers returns versionStr without the empty identifiers of
+// the build metadata starting at offset, and without the '+' when none is left
+func dropEmptyBuildIdentifiers(versionStr string, offset int) string {
+	var identifiers []string
+	for _, identifier := range strings.Split(versionStr[offset:], ".") {
+		if identifier != "" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	if len(identifiers) == 0 {
+		return versionStr[:offset-1]
+	}
+	return versionStr[:offset] + strings.Join(identifiers, ".")
 }
 
 // Validate checks if a version string is valid
@@ -251,8 +293,14 @@ func GetBuildTypeFromVersion(versionStr string) (string, error) {
 	return GetBuildType(targetVersion)
 }
 
+// OrderingContract is the revision of the ordering rules implemented by Compare,
+// see docs/Ordering.md. It is incremented whenever two versions change their order.
+const OrderingContract = 1
+
 // Compare compares two versions for sorting
 // Returns -1 if a < b, 0 if a == b, 1 if a > b
+// Prerelease and postrelease labels sort by the precedence of the label registry
+// of the scheme a or b was parsed with (Scheme.Parse), the current one otherwise.
 func Compare(a, b *Version) int {
 	// First compare major.minor.patch
 	if a.Major != b.Major {
@@ -264,18 +312,29 @@ func Compare(a, b *Version) int {
 	if a.Patch != b.Patch {
 		return a.Patch - b.Patch
 	}
+	if a.Extra != b.Extra {
+		if result := compareExtra(a.Extra, b.Extra); result != 0 {
+			return result
+		}
+	}
 	
 	// For same core version, compare by type precedence
 	if a.Type != b.Type {
 		return int(a.Type) - int(b.Type)
 	}
 	
-	// For same type, compare by type-specific identifiers
+	// For same type, compare by type-specific identifiers, split once by Parse
+	if aTokens, bTokens := a.cachedTokens(), b.cachedTokens(); aTokens != nil && bTokens != nil {
+		return compareTokens(aTokens, bTokens, versionScheme(a, b))
+	}
 	switch a.Type {
 	case TypePrerelease:
-		return compareIdentifiers(a.Prerelease, b.Prerelease)
+		if a.Dialect == DialectSemVer2 || b.Dialect == DialectSemVer2 {
+			return compareSemVer2Identifiers(a.Prerelease, b.Prerelease)
+		}
+		return compareLabeled(a.Prerelease, b.Prerelease, versionScheme(a, b))
 	case TypePostrelease:
-		return compareIdentifiers(a.Postrelease, b.Postrelease)
+		return compareLabeled(a.Postrelease, b.Postrelease, versionScheme(a, b))
 	case TypeIntermediate:
 		return compareIdentifiers(a.Intermediate, b.Intermediate)
 	default:
@@ -283,26 +342,18 @@ func Compare(a, b *Version) int {
 	}
 }
 
-// compareIdentifiers compares alphanumeric identifiers
+// compareIdentifiers compares alphanumeric identifiers part by part.
+// Parts are scanned in place, so the comparison does not allocate.
 func compareIdentifiers(a, b string) int {
-	// Split identifiers by dots and underscores
-	aParts := splitIdentifier(a)
-	bParts := splitIdentifier(b)
-	
-	// Compare each part
-	maxLen := len(aParts)
-	if len(bParts) > maxLen {
-		maxLen = len(bParts)
+	if a == b {
+		return 0
 	}
 	
-	for i := 0; i < maxLen; i++ {
-		var aPart, bPart string
-		if i < len(aParts) {
-			aPart = aParts[i]
-		}
-		if i < len(bParts) {
-			bPart = bParts[i]
-		}
+	var aPart, bPart string
+	i, j := 0, 0
+	for i <= len(a) || j <= len(b) {
+		aPart, i = nextIdentifierPart(a, i)
+		bPart, j = nextIdentifierPart(b, j)
 		
 		result := compareIdentifierPart(aPart, bPart)
 		if result != 0 {
@@ -313,6 +364,20 @@ func compareIdentifiers(a, b string) int {
 	return 0
 }
 
+// nextIdentifierPart returns the identifier part starting at offset i, up to
+// the next dot or underscore, and the offset of the following part.
+// It returns an empty part once the identifier is exhausted.
+func nextIdentifierPart(identifier string, i int) (string, int) {
+	if i > len(identifier) {
+		return "", i
+	}
+	j := i
+	for j < len(identifier) && identifier[j] != '.' && identifier[j] != '_' {
+		j++
+	}
+	return identifier[i:j], j + 1
+}
+
 // splitIdentifier splits an identifier by dots and underscores
 func splitIdentifier(identifier string) []string {
 	// Replace underscores with dots for consistent splitting
@@ -322,6 +387,11 @@ func splitIdentifier(identifier string) []string {
 
 // compareIdentifierPart compares two identifier parts
 func compareIdentifierPart(a, b string) int {
+	return compareParts(a, isNumericIdentifier(a), b, isNumericIdentifier(b))
+}
+
+// compareParts compares two identifier parts whose numeric kind is known
+func compareParts(a string, aNumeric bool, b string, bNumeric bool) int {
 	// Handle empty parts
 	if a == "" && b == "" {
 		return 0
@@ -333,20 +403,16 @@ func compareIdentifierPart(a, b string) int {
 		return 1
 	}
 	
-	// Try to parse as numbers
-	aNum, aErr := strconv.Atoi(a)
-	bNum, bErr := strconv.Atoi(b)
-	
 	// If both are numbers, compare numerically
-	if aErr == nil && bErr == nil {
-		return aNum - bNum
+	if aNumeric && bNumeric {
+		return compareNumericString(a, b)
 	}
 	
 	// If one is a number and the other isn't, number comes first
-	if aErr == nil && bErr != nil {
+	if aNumeric && !bNumeric {
 		return -1
 	}
-	if aErr != nil && bErr == nil {
+	if !aNumeric && bNumeric {
 		return 1
 	}
 	
@@ -355,42 +421,58 @@ func compareIdentifierPart(a, b string) int {
 }
 
 // Sort sorts a list of version strings according to precedence rules
-// Returns the sorted versions as a slice of strings
+// Returns the sorted versions as a slice of strings. Versions that are Equal
+// (e.g. v1.2.3, 1.2.3 and 1.2.3+build.1) appear once, as the first of them in the input.
 func Sort(versions []string) ([]string, error) {
+	return sortVersions(versions, CurrentCalVer(), nil)
+}
+
+// sortVersions sorts version strings parsed with a calendar versioning scheme and a scheme
+func sortVersions(versions []string, calver *CalVer, scheme *Scheme) ([]string, error) {
 	if len(versions) == 0 {
 		return []string{}, nil
 	}
 	
-	// Parse all versions
-	var parsedVersions []*Version
-	for _, v := range versions {
-		parsed, err := Parse(v)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version '%s': %v", v, err)
+	// Parse all versions into a single slice
+	parsedVersions := make([]Version, len(versions))
+	for i, v := range versions {
+		if err := parseInto(&parsedVersions[i], v, calver, scheme); err != nil {
+			return nil, fmt.Errorf("invalid version '%s': %w", v, err)
 		}
-		parsedVersions = append(parsedVersions, parsed)
 	}
 	
-	// Sort versions
-	sort.Slice(parsedVersions, func(i, j int) bool {
-		return Compare(parsedVersions[i], parsedVersions[j]) < 0
-	})
-	
-	// Build result
-	var result []string
-	for _, v := range parsedVersions {
-		result = append(result, v.Original)
+	pointers := make([]*Version, len(parsedVersions))
+	for i := range parsedVersions {
+		pointers[i] = &parsedVersions[i]
+	}
+	sorted := SortVersions(pointers)
+	result := make([]string, len(sorted))
+	for i, v := range sorted {
+		result[i] = v.Original
 	}
 	
 	return result, nil
 }
 
+// SortVersions sorts parsed versions in place according to precedence rules,
+// keeping the input order of versions with equal precedence (e.g. the same
+// version with different build metadata). It returns the sorted slice with
+// Equal versions removed, the first of them in the input is kept.
+func SortVersions(versions []*Version) []*Version {
+	slices.SortStableFunc(versions, Compare)
+	return slices.CompactFunc(versions, Equal)
+}
+
 // IsValid checks if a version string is valid
 func IsValid(versionStr string) bool {
 	return Validate(versionStr) == nil
 }
 
-// String returns the string representation of the version
+// String returns the string representation of the version.
+// For a Version built by hand without Original, the string is composed from its components.
 func (v *Version) String() string {
-	return v.Original
+	if v.Original != "" {
+		return v.Original
+	}
+	return fmt.Sprintf("%d.%d.%d%s%s%s%s%s", v.Major, v.Minor, v.Patch, v.Extra, v.Prerelease, v.Postrelease, v.Intermediate, v.Build)
 }
\ No newline at end of file
@@ -1,6 +1,10 @@
 package version
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -293,6 +297,13 @@ func TestCompareIdentifiers(t *testing.T) {
 		{"alpha.1", "alpha.1", 0},
 		{"alpha.1", "beta.1", -1},
 		{"beta.1", "alpha.1", 1},
+		{"alpha.10", "alpha.9", 1},
+		{"alpha.01", "alpha.1", 0},
+		{"alpha.1", "alpha.1.0", -1},
+		{"alpha.1_x", "alpha.1.2", 1},
+		{"~alpha.99999999999999999999", "~alpha.100000000000000000000", -1},
+		{"_feat_x", "_feat.1", 1},
+		{"", "", 0},
 	}
 
 	for _, test := range tests {
@@ -305,6 +316,68 @@ func TestCompareIdentifiers(t *testing.T) {
 	}
 }
 
+// loadOrderingCorpus reads the compatibility corpus of the current ordering
+// contract, a list of groups of equal versions in ascending order
+func loadOrderingCorpus(t *testing.T) [][]string {
+	t.Helper()
+	data, err := os.ReadFile(fmt.Sprintf("testdata/ordering-v%d.txt", OrderingContract))
+	if err != nil {
+		t.Fatalf("Failed to read ordering corpus: %v", err)
+	}
+
+	var groups [][]string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			groups = append(groups, strings.Fields(line))
+		}
+	}
+	return groups
+}
+
+func TestOrderingContract(t *testing.T) {
+	groups := loadOrderingCorpus(t)
+
+	type entry struct {
+		group   int
+		version *Version
+	}
+	var entries []entry
+	var reversed []string
+	for i, group := range groups {
+		for _, input := range group {
+			v, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", input, err)
+			}
+			entries = append(entries, entry{i, v})
+			reversed = append([]string{input}, reversed...)
+		}
+	}
+
+	for _, a := range entries {
+		for _, b := range entries {
+			want := sign(a.group - b.group)
+			if got := sign(Compare(a.version, b.version)); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a.version.Original, b.version.Original, got, want)
+			}
+		}
+	}
+
+	sorted, err := Sort(reversed)
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+	// Sort keeps one version of each group of equal versions
+	if len(sorted) != len(groups) {
+		t.Fatalf("Sort() returned %d versions, want one of each of the %d groups", len(sorted), len(groups))
+	}
+	for i, group := range groups {
+		if !slices.Contains(group, sorted[i]) {
+			t.Errorf("Sort()[%d] = %s, want one of %v", i, sorted[i], group)
+		}
+	}
+}
+
 func TestConvertGitTag(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -369,6 +442,26 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortVersions(t *testing.T) {
+	var versions []*Version
+	for _, input := range []string{"1.2.3+b", "1.2.3~rc.1", "v1.2.3", "1.2.3_feat.2", "1.2.3_feat.10", "1.2.3~rc.1+a"} {
+		v, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%s) failed: %v", input, err)
+		}
+		versions = append(versions, v)
+	}
+
+	var result []string
+	for _, v := range SortVersions(versions) {
+		result = append(result, v.Original)
+	}
+	expected := []string{"1.2.3~rc.1", "1.2.3+b", "1.2.3_feat.2", "1.2.3_feat.10"}
+	if strings.Join(result, " ") != strings.Join(expected, " ") {
+		t.Errorf("SortVersions = %v, want %v", result, expected)
+	}
+}
+
 func TestSortReleaseVsPrerelease(t *testing.T) {
 	// Test the specific scenario: v1.3.9 should be greater than v1.3.9-rc.9
 	versions := []string{
@@ -705,4 +798,137 @@ func TestGetBuildTypeFromVersionWithGit(t *testing.T) {
 		t.Errorf("GetBuildTypeFromVersion from git returned invalid type: %s", result)
 	}
 	t.Logf("GetBuildTypeFromVersion from git returned: %s", result)
-}
\ No newline at end of file
+}
+func TestParseBuildMetadata(t *testing.T) {
+	tests := []struct {
+		input    string
+		typ      Type
+		build    string
+		original string
+		hasError bool
+	}{
+		{"1.2.3+git.abc123", TypeRelease, "+git.abc123", "1.2.3+git.abc123", false},
+		{"v1.2.3+build.42", TypeRelease, "+build.42", "v1.2.3+build.42", false},
+		{"1.2.3~rc.1+build.42", TypePrerelease, "+build.42", "1.2.3~rc.1+build.42", false},
+		{"1.2.3-rc.1+build.42", TypePrerelease, "+build.42", "1.2.3~rc.1+build.42", false},
+		{"1.2.3.fix.1+20251016", TypePostrelease, "+20251016", "1.2.3.fix.1+20251016", false},
+		{"1.2.3_feat.1+exp-sha.5114f85", TypeIntermediate, "+exp-sha.5114f85", "1.2.3_feat.1+exp-sha.5114f85", false},
+		{"1.2.3+001", TypeRelease, "+001", "1.2.3+001", false},
+		{"1.2.3", TypeRelease, "", "1.2.3", false},
+		{"1.2.3+", TypeInvalid, "", "", true},
+		{"1.2.3+build..1", TypeInvalid, "", "", true},
+		{"1.2.3+build+1", TypeInvalid, "", "", true},
+		{"1.2.3+build_1", TypeInvalid, "", "", true},
+		{"1.2+build", TypeInvalid, "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := Parse(test.input)
+			if test.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input %s, but got none", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for input %s: %v", test.input, err)
+			}
+			if result.Type != test.typ {
+				t.Errorf("Type mismatch for %s: expected %v, got %v", test.input, test.typ, result.Type)
+			}
+			if result.Build != test.build {
+				t.Errorf("Build mismatch for %s: expected %s, got %s", test.input, test.build, result.Build)
+			}
+			if result.String() != test.original {
+				t.Errorf("String() mismatch for %s: expected %s, got %s", test.input, test.original, result.String())
+			}
+		})
+	}
+}
+
+func TestCompareIgnoresBuildMetadata(t *testing.T) {
+	a, _ := Parse("1.2.3~rc.1+build.1")
+	b, _ := Parse("1.2.3~rc.1+build.2")
+	if result := Compare(a, b); result != 0 {
+		t.Errorf("Compare(%s, %s) = %d, want 0", a, b, result)
+	}
+
+	c, _ := Parse("1.2.3+build.99")
+	d, _ := Parse("1.2.4+build.1")
+	if result := Compare(c, d); result >= 0 {
+		t.Errorf("Compare(%s, %s) = %d, want < 0", c, d, result)
+	}
+}
+
+func TestSortBuildMetadata(t *testing.T) {
+	result, err := Sort([]string{"1.2.4", "1.2.3+build.2", "1.2.3~rc.1+build.9", "1.2.3+build.1"})
+	if err != nil {
+		t.Fatalf("Sort failed: %v", err)
+	}
+
+	// Versions differing only in build metadata appear once, the first in the input
+	expected := []string{"1.2.3~rc.1+build.9", "1.2.3+build.2", "1.2.4"}
+	if len(result) != len(expected) {
+		t.Fatalf("Sort() = %v, want %v", result, expected)
+	}
+	for i, v := range result {
+		if v != expected[i] {
+			t.Errorf("Position %d: expected %s, got %s", i, expected[i], v)
+		}
+	}
+}
+
+func TestVersionStringFromComponents(t *testing.T) {
+	v := &Version{Major: 1, Minor: 2, Patch: 3, Type: TypePrerelease, Prerelease: "~rc.1", Build: "+build.42"}
+	if v.String() != "1.2.3~rc.1+build.42" {
+		t.Errorf("String() = %s, want 1.2.3~rc.1+build.42", v.String())
+	}
+}
+
+func TestCompareAllocations(t *testing.T) {
+	a, _ := Parse("1.2.3~alpha.1_feature.10")
+	b, _ := Parse("1.2.3~alpha.1_feature.9")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		Compare(a, b)
+	})
+	if allocs != 0 {
+		t.Errorf("Compare allocated %v times, want 0", allocs)
+	}
+}
+
+func TestParseAllocations(t *testing.T) {
+	tests := []struct {
+		input  string
+		allocs float64
+	}{
+		{"1.2.3", 1},
+		{"v1.2.3~rc.1_feature.2", 1},
+		{"1.2.3.fix.1+build.5", 1},
+		{"1.2.3-rc.1", 3}, // git tag delimiter is normalized
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				Parse(test.input)
+			})
+			if allocs > test.allocs {
+				t.Errorf("Parse(%s) allocated %v times, want at most %v", test.input, allocs, test.allocs)
+			}
+		})
+	}
+}
+
+func TestSortAllocations(t *testing.T) {
+	versions := []string{"1.2.3", "1.2.3~alpha.1", "1.2.3.fix.1", "1.2.3_feat", "0.1.0", "2.0.0+build"}
+
+	// The parsed versions, the pointers sorted by SortVersions and the result
+	allocs := testing.AllocsPerRun(100, func() {
+		Sort(versions)
+	})
+	if allocs > 3 {
+		t.Errorf("Sort allocated %v times, want at most 3", allocs)
+	}
+}